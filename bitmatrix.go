@@ -0,0 +1,185 @@
+package bitop
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// BitMatrix is a matrix of bits, each row is packed from left to right into 64-bit words as in BitString
+// Bits past the last column are always kept as zero
+type BitMatrix struct {
+	words  []uint64
+	rows   int
+	cols   int
+	stride int
+}
+
+// NewBitMatrix returns a rows × cols matrix with all bits set to zero
+func NewBitMatrix(rows, cols int) *BitMatrix {
+	rows, cols = max(rows, 0), max(cols, 0)
+	stride := (cols + 63) / 64
+	return &BitMatrix{words: make([]uint64, rows*stride), rows: rows, cols: cols, stride: stride}
+}
+
+// BitMatrixFromRows returns a matrix with one row per bit string, rows shorter than the longest are padded with zeros on the right
+func BitMatrixFromRows(rows []BitString) *BitMatrix {
+	cols := 0
+	for _, r := range rows {
+		cols = max(cols, r.leng)
+	}
+	m := NewBitMatrix(len(rows), cols)
+	for i, r := range rows {
+		copy(m.row(i), r.words)
+	}
+	return m
+}
+
+// BitMatrixFromUints returns a matrix in the input shape of ColumnJoin, one row per value holding its rightmost `cols` bits
+func BitMatrixFromUints(rows []uint, cols int) *BitMatrix {
+	m := NewBitMatrix(len(rows), cols)
+	for i, r := range rows {
+		for j := 0; j < m.cols && j < bits.UintSize; j++ {
+			m.Set(i, m.cols-1-j, r>>j&1)
+		}
+	}
+	return m
+}
+
+// Uints returns the rightmost bits of each row as a uint, the output shape of ColumnJoin
+// ColumnJoin(rows, colLeng) gives the same values as BitMatrixFromUints(rows, colLeng).Transpose().Uints()
+func (m *BitMatrix) Uints() []uint {
+	vs := make([]uint, m.rows)
+	for i := range vs {
+		n := min(m.cols, bits.UintSize)
+		vs[i] = uint(m.Row(i).bits(m.cols-n, n))
+	}
+	return vs
+}
+
+// Rows returns the number of rows in the matrix
+func (m *BitMatrix) Rows() int {
+	return m.rows
+}
+
+// Cols returns the number of columns in the matrix
+func (m *BitMatrix) Cols() int {
+	return m.cols
+}
+
+// Get returns the bit at row i and column j, index out of range returns zero
+func (m *BitMatrix) Get(i, j int) uint {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		return 0
+	}
+	return uint(m.words[i*m.stride+j/64]>>(63-j%64)) & 1
+}
+
+// Set sets the bit at row i and column j to the lowest bit of `bit`, index out of range is ignored
+func (m *BitMatrix) Set(i, j int, bit uint) {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		return
+	}
+	w := &m.words[i*m.stride+j/64]
+	*w = *w&^(1<<(63-j%64)) | uint64(bit&1)<<(63-j%64)
+}
+
+// Row returns a copy of row i as a bit string, index out of range returns an empty bit string
+func (m *BitMatrix) Row(i int) BitString {
+	if i < 0 || i >= m.rows {
+		return BitString{}
+	}
+	return BitString{words: append([]uint64(nil), m.row(i)...), leng: m.cols}
+}
+
+// Col returns a copy of column j as a bit string, index out of range returns an empty bit string
+func (m *BitMatrix) Col(j int) BitString {
+	if j < 0 || j >= m.cols {
+		return BitString{}
+	}
+	var s BitString
+	for i := 0; i < m.rows; i++ {
+		s.appendBits(uint64(m.Get(i, j)), 1)
+	}
+	return s
+}
+
+// SetRow replaces row i with the bit string, truncated or padded with zeros on the right to the number of columns
+func (m *BitMatrix) SetRow(i int, s BitString) {
+	if i < 0 || i >= m.rows {
+		return
+	}
+	r := m.row(i)
+	clear(r)
+	copy(r, s.slice(0, min(s.leng, m.cols)).words)
+}
+
+// Clone returns a copy of the matrix
+func (m *BitMatrix) Clone() *BitMatrix {
+	c := *m
+	c.words = append([]uint64(nil), m.words...)
+	return &c
+}
+
+// Equal returns true if both matrices have the same dimensions and bits
+func (m *BitMatrix) Equal(n *BitMatrix) bool {
+	if m.rows != n.rows || m.cols != n.cols {
+		return false
+	}
+	for i, w := range m.words {
+		if w != n.words[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Transpose returns the cols × rows matrix with rows and columns swapped
+// The matrix is transposed in blocks of 64 × 64 bits, each swapped in place in six steps
+func (m *BitMatrix) Transpose() *BitMatrix {
+	t := NewBitMatrix(m.cols, m.rows)
+	var block [64]uint64
+	for bi := 0; bi < m.rows; bi += 64 {
+		for bj := 0; bj < m.stride; bj++ {
+			n := min(64, m.rows-bi)
+			for k := 0; k < n; k++ {
+				block[k] = m.words[(bi+k)*m.stride+bj]
+			}
+			clear(block[n:])
+			transpose64(&block)
+			for k := 0; k < 64 && bj*64+k < m.cols; k++ {
+				t.words[(bj*64+k)*t.stride+bi/64] = block[k]
+			}
+		}
+	}
+	return t
+}
+
+// String returns the rows as strings of 0s and 1s, one per line
+func (m *BitMatrix) String() string {
+	var sb strings.Builder
+	for i := 0; i < m.rows; i++ {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(m.Row(i).String())
+	}
+	return sb.String()
+}
+
+// row returns the words of row i
+func (m *BitMatrix) row(i int) []uint64 {
+	return m.words[i*m.stride : (i+1)*m.stride]
+}
+
+// transpose64 transposes a 64 × 64 block by swapping the off-diagonal quarters of ever smaller blocks
+// See Hacker's Delight, section 7-3
+func transpose64(a *[64]uint64) {
+	m := uint64(0x00000000FFFFFFFF)
+	for j := 32; j != 0; j, m = j>>1, m^m<<(j>>1) {
+		for k := 0; k < 64; k = ((k | j) + 1) &^ j {
+			t := (a[k] ^ a[k|j]>>j) & m
+			a[k] ^= t
+			a[k|j] ^= t << j
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package bitop
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// randomBitMatrix returns a rows × cols matrix where each bit is one with probability p
+func randomBitMatrix(r *rand.Rand, rows, cols int, p float64) *BitMatrix {
+	m := NewBitMatrix(rows, cols)
+	for i := 0; i < rows; i++ {
+		m.SetRow(i, randomBitString(r, cols, p))
+	}
+	return m
+}
+
+func TestBitMatrixGetSet(t *testing.T) {
+	t.Parallel()
+	m := NewBitMatrix(3, 70)
+	m.Set(0, 0, 1)
+	m.Set(1, 69, 1)
+	m.Set(2, 64, 1)
+	m.Set(2, 64, 0)
+	m.Set(3, 0, 1)
+	m.Set(0, 70, 1)
+	for _, tc := range []struct {
+		i, j     int
+		expected uint
+	}{
+		{0, 0, 1},
+		{1, 69, 1},
+		{2, 64, 0},
+		{0, 1, 0},
+		{-1, 0, 0},
+		{0, 70, 0},
+	} {
+		if result := m.Get(tc.i, tc.j); result != tc.expected {
+			t.Fatalf("[TestBitMatrixGetSet][%d, %d]: Got %v, expected %v", tc.i, tc.j, result, tc.expected)
+		}
+	}
+	if result, expected := m.Row(1), strings.Repeat("0", 69)+"1"; result.String() != expected {
+		t.Fatalf("[TestBitMatrixGetSet][row]: Got %v, expected %v", result, expected)
+	}
+	if result := m.Col(0); result.String() != "100" {
+		t.Fatalf("[TestBitMatrixGetSet][col]: Got %v, expected %v", result, "100")
+	}
+}
+
+func TestBitMatrixFromRows(t *testing.T) {
+	t.Parallel()
+	m := BitMatrixFromRows([]BitString{bs("101"), bs("1"), bs("")})
+	if expected := "101\n100\n000"; m.String() != expected {
+		t.Fatalf("[TestBitMatrixFromRows]: Got %v, expected %v", m, expected)
+	}
+	m.SetRow(2, bs("0111"))
+	if expected := "101\n100\n011"; m.String() != expected {
+		t.Fatalf("[TestBitMatrixFromRows][set row]: Got %v, expected %v", m, expected)
+	}
+}
+
+func TestBitMatrixTranspose(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name string
+		rows int
+		cols int
+	}{
+		{"empty", 0, 0},
+		{"single bit", 1, 1},
+		{"row", 1, 100},
+		{"column", 100, 1},
+		{"block", 64, 64},
+		{"uneven blocks", 70, 130},
+		{"many blocks", 200, 150},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			m := randomBitMatrix(rand.New(rand.NewSource(int64(tc.rows*tc.cols))), tc.rows, tc.cols, 0.5)
+			tr := m.Transpose()
+			if tr.Rows() != tc.cols || tr.Cols() != tc.rows {
+				t.Fatalf("[TestBitMatrixTranspose][%s]: Got %d × %d, expected %d × %d", tc.name, tr.Rows(), tr.Cols(), tc.cols, tc.rows)
+			}
+			for i := 0; i < tc.rows; i++ {
+				for j := 0; j < tc.cols; j++ {
+					if tr.Get(j, i) != m.Get(i, j) {
+						t.Fatalf("[TestBitMatrixTranspose][%s]: Got %v at (%d, %d), expected %v", tc.name, tr.Get(j, i), j, i, m.Get(i, j))
+					}
+				}
+			}
+			for j := 0; j < tc.cols; j++ {
+				if !tr.Row(j).Equal(m.Col(j)) {
+					t.Fatalf("[TestBitMatrixTranspose][%s]: Got row %v, expected %v", tc.name, tr.Row(j), m.Col(j))
+				}
+			}
+			if !tr.Transpose().Equal(m) {
+				t.Fatalf("[TestBitMatrixTranspose][%s]: transposing twice does not give back the matrix", tc.name)
+			}
+		})
+	}
+}
+
+func TestBitMatrixColumnJoin(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name    string
+		rows    []uint
+		colLeng int
+	}{
+		{"same lengths", []uint{0b1010, 0b0101, 0b1110, 0b0111, 0b1100}, 4},
+		{"variable lengths", []uint{0b1, 0b111, 0b10, 0b0}, 3},
+		{"full word", []uint{^uint(0), 1 << 63, 1}, 64},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			expected := ColumnJoin(tc.rows, tc.colLeng)
+			m := BitMatrixFromUints(tc.rows, tc.colLeng)
+			result := m.Transpose().Uints()
+			if fmt.Sprint(result) != fmt.Sprint(expected) {
+				t.Fatalf("[TestBitMatrixColumnJoin][%s]: Got %b, expected %b", tc.name, result, expected)
+			}
+			if back := m.Uints(); fmt.Sprint(back) != fmt.Sprint(tc.rows) {
+				t.Fatalf("[TestBitMatrixColumnJoin][%s]: Got %b, expected %b", tc.name, back, tc.rows)
+			}
+		})
+	}
+}
+
+func BenchmarkTranspose(b *testing.B) {
+	m := randomBitMatrix(rand.New(rand.NewSource(1)), 1024, 1024, 0.5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Transpose()
+	}
+}
+
+func BenchmarkTransposeNaive(b *testing.B) {
+	m := randomBitMatrix(rand.New(rand.NewSource(1)), 1024, 1024, 0.5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		t := NewBitMatrix(m.Cols(), m.Rows())
+		for r := 0; r < m.Rows(); r++ {
+			for c := 0; c < m.Cols(); c++ {
+				t.Set(c, r, m.Get(r, c))
+			}
+		}
+	}
+}
@@ -21,28 +21,60 @@ func NewUnit(b uint, leng int) Unit {
 
 // Contains returns true if the binary `b` has at least one section that matches with binary `sub`
 func Contains(b, sub Unit) bool {
+	return Index(b, sub) >= 0
+}
+
+// Index returns the first index of the given bit pattern, if no matching found -1 is returned
+func Index(b, sub Unit) int {
 	for i := 0; i <= b.leng-sub.leng; i++ {
-		window := TruncateFromLeft(b, i)
-		window = TruncateFromRight(window, b.leng-i-sub.leng)
-		if window == sub.value {
-			return true
+		if matchAt(b, sub, i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexAll returns every index where the given bit pattern matches, in increasing order
+// Matches may share bits if overlapping is true, otherwise the search resumes after the end of each match
+func IndexAll(b, sub Unit, overlapping bool) []int {
+	var inds []int
+	for i := 0; i <= b.leng-sub.leng; {
+		if !matchAt(b, sub, i) {
+			i++
+			continue
+		}
+		inds = append(inds, i)
+		if overlapping || sub.leng == 0 {
+			i++
+		} else {
+			i += sub.leng
 		}
 	}
-	return false
+	return inds
+}
+
+// Count returns the number of non-overlapping matches of the bit pattern, an empty pattern matches at every index
+func Count(b, sub Unit) int {
+	return len(IndexAll(b, sub, false))
 }
 
 // LastIndex returns the last index of the given bit pattern, if no matching found -1 is returned
 func LastIndex(b, sub Unit) int {
-	for i := 0; i <= b.leng-sub.leng; i++ {
-		window := TruncateFromLeft(b, b.leng-i-sub.leng)
-		window = TruncateFromRight(window, i)
-		if window == sub.value {
-			return b.leng - i - sub.leng
+	for i := b.leng - sub.leng; i >= 0; i-- {
+		if matchAt(b, sub, i) {
+			return i
 		}
 	}
 	return -1
 }
 
+// matchAt returns true if the bits of `b` starting at index `ind` match with binary `sub`
+func matchAt(b, sub Unit, ind int) bool {
+	window := TruncateFromLeft(b, ind)
+	window = TruncateFromRight(window, b.leng-ind-sub.leng)
+	return window == sub.value
+}
+
 // GetBitAtIndex returns the bit at index `ind` of the given binary, index counting from left to right from zero as usual
 func GetBitAtIndex(b Unit, ind int) uint {
 	if ind < 0 {
@@ -60,6 +92,80 @@ func SplitAt(b Unit, ind int) []uint {
 	return []uint{firstHalf, b.value ^ (firstHalf << (b.leng - ind))}
 }
 
+// Split slices the binary into all sub-binaries separated by `sep`, with the lengths of the sub-binaries preserved
+// If `sep` is empty, the binary is split into single bits
+func Split(b, sep Unit) []Unit {
+	return genSplit(b, sep, 0, -1)
+}
+
+// SplitN slices the binary into sub-binaries separated by `sep`, returning at most n sub-binaries
+// The last sub-binary is the unsplit remainder, n == 0 returns nil and n < 0 returns all sub-binaries
+func SplitN(b, sep Unit, n int) []Unit {
+	return genSplit(b, sep, 0, n)
+}
+
+// SplitAfter slices the binary into all sub-binaries after each instance of `sep`, keeping `sep` at the end of each sub-binary
+func SplitAfter(b, sep Unit) []Unit {
+	return genSplit(b, sep, sep.leng, -1)
+}
+
+// Fields splits the binary around each run of consecutive zeros, returning the runs of ones
+func Fields(b Unit) []Unit {
+	var fields []Unit
+	start := -1
+	for i := 0; i <= b.leng; i++ {
+		if i < b.leng && GetBitAtIndex(b, i) == 1 {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			fields = append(fields, slice(b, start, i))
+			start = -1
+		}
+	}
+	return fields
+}
+
+// genSplit splits the binary at each `sep`, keeping sepSave bits of `sep` in the sub-binaries, up to n sub-binaries
+func genSplit(b, sep Unit, sepSave, n int) []Unit {
+	if n == 0 {
+		return nil
+	}
+	if sep.leng == 0 {
+		if n < 0 || n > b.leng {
+			n = b.leng
+		}
+		split := make([]Unit, 0, n)
+		for i := 0; i < n-1; i++ {
+			split = append(split, slice(b, i, i+1))
+		}
+		if n > 0 {
+			split = append(split, slice(b, n-1, b.leng))
+		}
+		return split
+	}
+
+	var split []Unit
+	start := 0
+	for i := 0; i <= b.leng-sep.leng && (n < 0 || len(split) < n-1); {
+		if matchAt(b, sep, i) {
+			split = append(split, slice(b, start, i+sepSave))
+			i += sep.leng
+			start = i
+		} else {
+			i++
+		}
+	}
+	return append(split, slice(b, start, b.leng))
+}
+
+// slice returns the sub-binary of bits from index i to j, [i, j)
+func slice(b Unit, i, j int) Unit {
+	return Unit{value: b.value >> uint(b.leng-j) & mask(j-i), leng: j - i}
+}
+
 // TruncateFromRight returns the binary truncated up to the index from the right, exclusive of the index `ind`
 func TruncateFromRight(b uint, pos int) uint {
 	if pos < 0 {
@@ -131,20 +237,25 @@ func Repeat(b Unit, count int) uint {
 
 // Replace returns a binary with any old bit pattern replaced by new, up to n times of occurrences
 func Replace(b Unit, old Unit, new Unit, n int) uint {
+	return replace(b, old, new, n).value
+}
+
+// replace returns the binary with old replaced by new up to n times, along with its length capped at the size of a uint
+func replace(b Unit, old Unit, new Unit, n int) Unit {
 	if n < 0 {
-		return b.value
+		return b
 	}
 
-	result := uint(0)
+	result := Unit{}
 	for i := 0; i < b.leng; {
-		window := TruncateFromLeft(b, i)
-		window = TruncateFromRight(window, b.leng-i-old.leng)
-		if window == old.value && n > 0 {
-			result = result<<new.leng | new.value
+		if n > 0 && i <= b.leng-old.leng && matchAt(b, old, i) {
+			result.value = result.value<<new.leng | new.value
+			result.leng = min(result.leng+new.leng, bits.UintSize)
 			n--
 			i += old.leng
 		} else {
-			result = result<<1 | GetBitAtIndex(b, i)
+			result.value = result.value<<1 | GetBitAtIndex(b, i)
+			result.leng = min(result.leng+1, bits.UintSize)
 			i++
 		}
 	}
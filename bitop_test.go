@@ -104,6 +104,161 @@ func TestLastIndex(t *testing.T) {
 	}
 }
 
+func TestIndex(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		sub      Unit
+		expected int
+	}{
+		{
+			name:     "ones",
+			b:        NewUnit(0b111111, -1),
+			sub:      NewUnit(0b11, -1),
+			expected: 0,
+		},
+		{
+			name:     "zeroes",
+			b:        NewUnit(0b000000, 6),
+			sub:      NewUnit(0b11, -1),
+			expected: -1,
+		},
+		{
+			name:     "leading zero",
+			b:        NewUnit(0b010101, 6),
+			sub:      NewUnit(0b01, 2),
+			expected: 0,
+		},
+		{
+			name:     "bit zero",
+			b:        NewUnit(0b110110, 6),
+			sub:      NewUnit(0b0, 1),
+			expected: 2,
+		},
+		{
+			name:     "longer sub",
+			b:        NewUnit(0b11, 2),
+			sub:      NewUnit(0b110, 3),
+			expected: -1,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := Index(tc.b, tc.sub)
+			if result != tc.expected {
+				t.Fatalf("[TestIndex][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestIndexAll(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name        string
+		b           Unit
+		sub         Unit
+		overlapping bool
+		expected    []int
+	}{
+		{
+			name:        "ones overlapping",
+			b:           NewUnit(0b11111, -1),
+			sub:         NewUnit(0b11, -1),
+			overlapping: true,
+			expected:    []int{0, 1, 2, 3},
+		},
+		{
+			name:        "ones non-overlapping",
+			b:           NewUnit(0b11111, -1),
+			sub:         NewUnit(0b11, -1),
+			overlapping: false,
+			expected:    []int{0, 2},
+		},
+		{
+			name:        "no match",
+			b:           NewUnit(0b000000, 6),
+			sub:         NewUnit(0b1, 1),
+			overlapping: true,
+			expected:    nil,
+		},
+		{
+			name:        "0b1010101",
+			b:           NewUnit(0b1010101, -1),
+			sub:         NewUnit(0b101, -1),
+			overlapping: false,
+			expected:    []int{0, 4},
+		},
+		{
+			name:        "empty sub",
+			b:           NewUnit(0b10, 2),
+			sub:         NewUnit(0, 0),
+			overlapping: false,
+			expected:    []int{0, 1, 2},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := IndexAll(tc.b, tc.sub, tc.overlapping)
+			if len(result) != len(tc.expected) {
+				t.Fatalf("[TestIndexAll][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			for i, r := range result {
+				if r != tc.expected[i] {
+					t.Fatalf("[TestIndexAll][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		sub      Unit
+		expected int
+	}{
+		{
+			name:     "ones",
+			b:        NewUnit(0b111111, -1),
+			sub:      NewUnit(0b11, -1),
+			expected: 3,
+		},
+		{
+			name:     "zeroes",
+			b:        NewUnit(0b000000, 6),
+			sub:      NewUnit(0b000, 3),
+			expected: 2,
+		},
+		{
+			name:     "no match",
+			b:        NewUnit(0b110110, 6),
+			sub:      NewUnit(0b111, 3),
+			expected: 0,
+		},
+		{
+			name:     "empty sub",
+			b:        NewUnit(0b110110, 6),
+			sub:      NewUnit(0, 0),
+			expected: 7,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := Count(tc.b, tc.sub)
+			if result != tc.expected {
+				t.Fatalf("[TestCount][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
 func TestGetBitAtIndex(t *testing.T) {
 	t.Parallel()
 	for _, tc := range []struct {
@@ -194,6 +349,188 @@ func TestSplitAt(t *testing.T) {
 	}
 }
 
+func TestSplit(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		sep      Unit
+		expected []Unit
+	}{
+		{
+			name:     "leading zeroes",
+			b:        NewUnit(0b0010011, 7),
+			sep:      NewUnit(0b1, 1),
+			expected: []Unit{NewUnit(0b00, 2), NewUnit(0b00, 2), NewUnit(0b0, 0), NewUnit(0b0, 0)},
+		},
+		{
+			name:     "no match",
+			b:        NewUnit(0b000000, 6),
+			sep:      NewUnit(0b11, 2),
+			expected: []Unit{NewUnit(0b000000, 6)},
+		},
+		{
+			name:     "multiple separator",
+			b:        NewUnit(0b10110101011100000, 17),
+			sep:      NewUnit(0b010, 3),
+			expected: []Unit{NewUnit(0b1011, 4), NewUnit(0b1011100000, 10)},
+		},
+		{
+			name:     "empty separator",
+			b:        NewUnit(0b010, 3),
+			sep:      NewUnit(0b0, 0),
+			expected: []Unit{NewUnit(0b0, 1), NewUnit(0b1, 1), NewUnit(0b0, 1)},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := Split(tc.b, tc.sep)
+			if len(result) != len(tc.expected) {
+				t.Fatalf("[TestSplit][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			for i, r := range result {
+				if r != tc.expected[i] {
+					t.Fatalf("[TestSplit][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+				}
+			}
+			if tc.sep.leng > 0 && Join(result, tc.sep) != tc.b.value {
+				t.Fatalf("[TestSplit][%s]: Got %02b, expected %02b after Join", tc.name, Join(result, tc.sep), tc.b.value)
+			}
+		})
+	}
+}
+
+func TestSplitN(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		sep      Unit
+		n        int
+		expected []Unit
+	}{
+		{
+			name:     "zero",
+			b:        NewUnit(0b0010011, 7),
+			sep:      NewUnit(0b1, 1),
+			n:        0,
+			expected: nil,
+		},
+		{
+			name:     "one",
+			b:        NewUnit(0b0010011, 7),
+			sep:      NewUnit(0b1, 1),
+			n:        1,
+			expected: []Unit{NewUnit(0b0010011, 7)},
+		},
+		{
+			name:     "remainder",
+			b:        NewUnit(0b0010011, 7),
+			sep:      NewUnit(0b1, 1),
+			n:        2,
+			expected: []Unit{NewUnit(0b00, 2), NewUnit(0b0011, 4)},
+		},
+		{
+			name:     "all",
+			b:        NewUnit(0b0010011, 7),
+			sep:      NewUnit(0b1, 1),
+			n:        -1,
+			expected: []Unit{NewUnit(0b00, 2), NewUnit(0b00, 2), NewUnit(0b0, 0), NewUnit(0b0, 0)},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := SplitN(tc.b, tc.sep, tc.n)
+			if len(result) != len(tc.expected) {
+				t.Fatalf("[TestSplitN][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			for i, r := range result {
+				if r != tc.expected[i] {
+					t.Fatalf("[TestSplitN][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitAfter(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		sep      Unit
+		expected []Unit
+	}{
+		{
+			name:     "leading zeroes",
+			b:        NewUnit(0b0010011, 7),
+			sep:      NewUnit(0b1, 1),
+			expected: []Unit{NewUnit(0b001, 3), NewUnit(0b001, 3), NewUnit(0b1, 1), NewUnit(0b0, 0)},
+		},
+		{
+			name:     "no match",
+			b:        NewUnit(0b000000, 6),
+			sep:      NewUnit(0b11, 2),
+			expected: []Unit{NewUnit(0b000000, 6)},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := SplitAfter(tc.b, tc.sep)
+			if len(result) != len(tc.expected) {
+				t.Fatalf("[TestSplitAfter][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			for i, r := range result {
+				if r != tc.expected[i] {
+					t.Fatalf("[TestSplitAfter][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		expected []Unit
+	}{
+		{
+			name:     "zeroes",
+			b:        NewUnit(0b000000, 6),
+			expected: nil,
+		},
+		{
+			name:     "ones",
+			b:        NewUnit(0b111111, -1),
+			expected: []Unit{NewUnit(0b111111, 6)},
+		},
+		{
+			name:     "mixed",
+			b:        NewUnit(0b0110001011, 10),
+			expected: []Unit{NewUnit(0b11, 2), NewUnit(0b1, 1), NewUnit(0b11, 2)},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := Fields(tc.b)
+			if len(result) != len(tc.expected) {
+				t.Fatalf("[TestFields][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			for i, r := range result {
+				if r != tc.expected[i] {
+					t.Fatalf("[TestFields][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+				}
+			}
+		})
+	}
+}
+
 func TestTruncateFromRight(t *testing.T) {
 	t.Parallel()
 	for _, tc := range []struct {
@@ -538,6 +875,14 @@ func TestReplace(t *testing.T) {
 			n:        2,
 			expected: 0b000000,
 		},
+		{
+			name:     "match cut off at the end",
+			b:        NewUnit(0b11, 2),
+			old:      NewUnit(0b01, 2),
+			new:      NewUnit(0b00, 2),
+			n:        1,
+			expected: 0b11,
+		},
 		{
 			name:     "complex match",
 			b:        NewUnit(0b1010101, -1),
@@ -546,6 +891,22 @@ func TestReplace(t *testing.T) {
 			n:        3,
 			expected: 0b101,
 		},
+		{
+			name:     "empty old",
+			b:        NewUnit(0b101, 3),
+			old:      NewUnit(0, 0),
+			new:      NewUnit(0b11, 2),
+			n:        2,
+			expected: 0b1111101,
+		},
+		{
+			name:     "empty old inserts at front",
+			b:        NewUnit(0b101, 3),
+			old:      NewUnit(0, 0),
+			new:      NewUnit(0b11, 2),
+			n:        10,
+			expected: 0b11111111111111111111101,
+		},
 	} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,128 @@
+package bitop
+
+import (
+	"io"
+	"math/bits"
+)
+
+// BitReader reads a stream bit by bit from left to right, starting from the most significant bit of each byte
+// It buffers the underlying reader, so it may read more bytes than the bits consumed
+type BitReader struct {
+	r   io.Reader
+	buf []byte
+	pos int
+	off int64
+	err error
+}
+
+// NewBitReader returns a new BitReader reading from r
+func NewBitReader(r io.Reader) *BitReader {
+	return &BitReader{r: r}
+}
+
+// ReadBit reads a single bit
+func (br *BitReader) ReadBit() (uint, error) {
+	if err := br.fill(1); err != nil {
+		return 0, err
+	}
+	bit := uint(br.peek(1))
+	br.advance(1)
+	return bit, nil
+}
+
+// ReadBits reads the next n bits as a Unit of length n, leading zeros included
+// If the stream ends before n bits, nothing is consumed and io.ErrUnexpectedEOF is returned
+func (br *BitReader) ReadBits(n int) (Unit, error) {
+	u, err := br.PeekBits(n)
+	if err != nil {
+		return Unit{}, err
+	}
+	br.advance(n)
+	return u, nil
+}
+
+// PeekBits returns the next n bits as a Unit without consuming them
+func (br *BitReader) PeekBits(n int) (Unit, error) {
+	if n < 0 || n > bits.UintSize {
+		return Unit{}, ErrLengthOverflow
+	}
+	if err := br.fill(n); err != nil {
+		return Unit{}, err
+	}
+	return Unit{value: br.peek(n), leng: n}, nil
+}
+
+// SkipBits discards the next n bits
+// Unlike ReadBits, a skip past the end of the stream discards the bits that remain, so Offset tells how far it got,
+// and returns io.ErrUnexpectedEOF, or io.EOF if there were no bits left
+func (br *BitReader) SkipBits(n int64) error {
+	for skipped := int64(0); n > 0; {
+		if err := br.fill(1); err != nil {
+			if err == io.EOF && skipped > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		k := int64(len(br.buf)*8 - br.pos)
+		if k > n {
+			k = n
+		}
+		br.advance(int(k))
+		skipped += k
+		n -= k
+	}
+	return nil
+}
+
+// Align discards the bits up to the next byte boundary and returns the number of bits discarded
+func (br *BitReader) Align() int {
+	n := int(-br.off & 7)
+	br.advance(n)
+	return n
+}
+
+// Offset returns the number of bits consumed so far
+func (br *BitReader) Offset() int64 {
+	return br.off
+}
+
+// fill buffers at least n unread bits, returning io.EOF if there are none left
+// and io.ErrUnexpectedEOF if the stream ends with fewer than n bits
+func (br *BitReader) fill(n int) error {
+	for len(br.buf)*8-br.pos < n {
+		if br.err != nil {
+			if br.err == io.EOF && len(br.buf)*8 > br.pos {
+				return io.ErrUnexpectedEOF
+			}
+			return br.err
+		}
+		if k := br.pos / 8; k > 0 {
+			br.buf = br.buf[:copy(br.buf, br.buf[k:])]
+			br.pos -= k * 8
+		}
+		if cap(br.buf)-len(br.buf) < 512 {
+			buf := make([]byte, len(br.buf), len(br.buf)+512)
+			copy(buf, br.buf)
+			br.buf = buf
+		}
+		m, err := br.r.Read(br.buf[len(br.buf):cap(br.buf)])
+		br.buf = br.buf[:len(br.buf)+m]
+		br.err = err
+	}
+	return nil
+}
+
+// peek returns the next n buffered bits
+func (br *BitReader) peek(n int) uint {
+	v := uint(0)
+	for i := br.pos; i < br.pos+n; i++ {
+		v = v<<1 | uint(br.buf[i/8]>>(7-i%8)&1)
+	}
+	return v
+}
+
+// advance consumes n buffered bits
+func (br *BitReader) advance(n int) {
+	br.pos += n
+	br.off += int64(n)
+}
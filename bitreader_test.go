@@ -0,0 +1,137 @@
+package bitop
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func TestBitReaderReadBits(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		data     []byte
+		widths   []int
+		expected []Unit
+		err      error
+	}{
+		{
+			name:     "unaligned fields",
+			data:     []byte{0b10110011, 0b01010111},
+			widths:   []int{3, 5, 1, 7},
+			expected: []Unit{NewUnit(0b101, 3), NewUnit(0b10011, 5), NewUnit(0b0, 1), NewUnit(0b1010111, 7)},
+		},
+		{
+			name:     "across bytes",
+			data:     []byte{0b00000001, 0b11111111, 0b10000000},
+			widths:   []int{7, 11},
+			expected: []Unit{NewUnit(0b0, 7), NewUnit(0b11111111110, 11)},
+		},
+		{
+			name:     "full word",
+			data:     []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00},
+			widths:   []int{4, 64},
+			expected: []Unit{NewUnit(0b1111, 4), NewUnit(0xFFFFFFFFFFFFFFF0, 64)},
+		},
+		{
+			name:     "unexpected eof",
+			data:     []byte{0xFF},
+			widths:   []int{3, 6},
+			expected: []Unit{NewUnit(0b111, 3)},
+			err:      io.ErrUnexpectedEOF,
+		},
+		{
+			name:     "eof",
+			data:     []byte{0xFF},
+			widths:   []int{8, 1},
+			expected: []Unit{NewUnit(0xFF, 8)},
+			err:      io.EOF,
+		},
+		{
+			name:   "too many bits",
+			data:   []byte{0xFF},
+			widths: []int{65},
+			err:    ErrLengthOverflow,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			br := NewBitReader(iotest.OneByteReader(bytes.NewReader(tc.data)))
+			var result []Unit
+			var err error
+			for _, n := range tc.widths {
+				var u Unit
+				if u, err = br.ReadBits(n); err != nil {
+					break
+				}
+				result = append(result, u)
+			}
+			if err != tc.err || len(result) != len(tc.expected) {
+				t.Fatalf("[TestBitReaderReadBits][%s]: Got %v %v, expected %v %v", tc.name, result, err, tc.expected, tc.err)
+			}
+			for i, r := range result {
+				if r != tc.expected[i] {
+					t.Fatalf("[TestBitReaderReadBits][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestBitReaderPeekSkipAlign(t *testing.T) {
+	t.Parallel()
+	br := NewBitReader(bytes.NewReader([]byte{0b10110011, 0b01010111, 0b11000000}))
+
+	if u, err := br.PeekBits(4); err != nil || u != NewUnit(0b1011, 4) {
+		t.Fatalf("[TestBitReaderPeekSkipAlign][peek]: Got %v %v, expected %v", u, err, NewUnit(0b1011, 4))
+	}
+	if bit, err := br.ReadBit(); err != nil || bit != 1 {
+		t.Fatalf("[TestBitReaderPeekSkipAlign][read bit]: Got %v %v, expected %v", bit, err, 1)
+	}
+	if n := br.Align(); n != 7 || br.Offset() != 8 {
+		t.Fatalf("[TestBitReaderPeekSkipAlign][align]: Got %v at %v, expected %v at %v", n, br.Offset(), 7, 8)
+	}
+	if n := br.Align(); n != 0 {
+		t.Fatalf("[TestBitReaderPeekSkipAlign][aligned]: Got %v, expected %v", n, 0)
+	}
+	if err := br.SkipBits(9); err != nil || br.Offset() != 17 {
+		t.Fatalf("[TestBitReaderPeekSkipAlign][skip]: Got %v at %v, expected offset %v", err, br.Offset(), 17)
+	}
+	if u, err := br.ReadBits(2); err != nil || u != NewUnit(0b10, 2) {
+		t.Fatalf("[TestBitReaderPeekSkipAlign][read]: Got %v %v, expected %v", u, err, NewUnit(0b10, 2))
+	}
+	if err := br.SkipBits(10); err != io.ErrUnexpectedEOF || br.Offset() != 24 {
+		t.Fatalf("[TestBitReaderPeekSkipAlign][skip past end]: Got %v at %v, expected %v at %v", err, br.Offset(), io.ErrUnexpectedEOF, 24)
+	}
+	if err := br.SkipBits(1); err != io.EOF || br.Offset() != 24 {
+		t.Fatalf("[TestBitReaderPeekSkipAlign][skip at end]: Got %v at %v, expected %v at %v", err, br.Offset(), io.EOF, 24)
+	}
+}
+
+func TestBitReaderShortSkip(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name   string
+		data   []byte
+		skip   int64
+		offset int64
+		err    error
+	}{
+		{"within stream", []byte{0xFF}, 5, 5, nil},
+		{"whole stream", []byte{0xFF}, 8, 8, nil},
+		{"past end", []byte{0xFF}, 10, 8, io.ErrUnexpectedEOF},
+		{"empty stream", nil, 1, 0, io.EOF},
+		{"nothing", nil, 0, 0, nil},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			br := NewBitReader(bytes.NewReader(tc.data))
+			if err := br.SkipBits(tc.skip); err != tc.err || br.Offset() != tc.offset {
+				t.Fatalf("[TestBitReaderShortSkip][%s]: Got %v at %v, expected %v at %v", tc.name, err, br.Offset(), tc.err, tc.offset)
+			}
+		})
+	}
+}
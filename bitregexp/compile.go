@@ -0,0 +1,186 @@
+package bitregexp
+
+// opcode is the operation of an instruction of the compiled program
+type opcode int
+
+const (
+	opBit opcode = iota
+	opAny
+	opFail
+	opSplit
+	opJump
+	opSave
+	opBegin
+	opEnd
+	opMatch
+)
+
+// inst is an instruction of the compiled program, execution continues at out
+// opSplit also continues at arg with lower priority, opBit compares with arg and opSave stores into slot arg
+type inst struct {
+	op  opcode
+	out int
+	arg int
+}
+
+// compiler lays out the program sequentially, each instruction continues at the next one unless patched
+type compiler struct {
+	prog []inst
+}
+
+// compile returns the program matching the expression, saving the bounds of the whole match in slots 0 and 1
+func compile(n *node) []inst {
+	c := &compiler{}
+	c.emit(inst{op: opSave, arg: 0})
+	c.node(n)
+	c.emit(inst{op: opSave, arg: 1})
+	c.emit(inst{op: opMatch})
+	return c.prog
+}
+
+// emit appends an instruction continuing at the next one and returns its index
+func (c *compiler) emit(i inst) int {
+	i.out = len(c.prog) + 1
+	c.prog = append(c.prog, i)
+	return len(c.prog) - 1
+}
+
+// split emits a split preferring the next instruction, or the patched target if not greedy
+func (c *compiler) split() int {
+	return c.emit(inst{op: opSplit})
+}
+
+// patchSplit sets the other branch of a split, swapping the branches if not greedy
+func (c *compiler) patchSplit(pc, target int, greedy bool) {
+	if greedy {
+		c.prog[pc].arg = target
+	} else {
+		c.prog[pc].arg, c.prog[pc].out = c.prog[pc].out, target
+	}
+}
+
+func (c *compiler) node(n *node) {
+	switch n.kind {
+	case nodeBit:
+		c.emit(inst{op: opBit, arg: int(n.bit)})
+	case nodeAny:
+		c.emit(inst{op: opAny})
+	case nodeFail:
+		c.emit(inst{op: opFail})
+	case nodeEmpty:
+	case nodeBegin:
+		c.emit(inst{op: opBegin})
+	case nodeEnd:
+		c.emit(inst{op: opEnd})
+	case nodeConcat:
+		for _, sub := range n.subs {
+			c.node(sub)
+		}
+	case nodeAlternate:
+		var jumps []int
+		for i, sub := range n.subs {
+			if i == len(n.subs)-1 {
+				c.node(sub)
+				break
+			}
+			pc := c.split()
+			c.node(sub)
+			jumps = append(jumps, c.emit(inst{op: opJump}))
+			c.prog[pc].arg = len(c.prog)
+		}
+		for _, pc := range jumps {
+			c.prog[pc].out = len(c.prog)
+		}
+	case nodeCapture:
+		c.emit(inst{op: opSave, arg: 2 * n.capture})
+		c.node(n.subs[0])
+		c.emit(inst{op: opSave, arg: 2*n.capture + 1})
+	case nodeRepeat:
+		c.repeat(n)
+	}
+}
+
+// repeat emits a repetition in the shapes Go's regexp uses, so matches have the same priorities:
+// x* is a loop, x+ is x followed by a loop back, x{n,} is n-1 copies of x followed by x+,
+// and x{n,m} is n copies of x followed by m-n nested optional copies, (x(x)?)?
+func (c *compiler) repeat(n *node) {
+	sub := n.subs[0]
+	if n.max < 0 {
+		if n.min == 0 {
+			c.star(sub, n.greedy)
+			return
+		}
+		for i := 1; i < n.min; i++ {
+			c.node(sub)
+		}
+		c.plus(sub, n.greedy)
+		return
+	}
+	for i := 0; i < n.min; i++ {
+		c.node(sub)
+	}
+	c.optional(sub, n.max-n.min, n.greedy)
+}
+
+// star emits x*, as (x+)? if x can match empty so that an empty iteration is not lost to a lower priority branch
+// See golang.org/issue/46123
+func (c *compiler) star(sub *node, greedy bool) {
+	// L0: split L1, L2; L1: sub+; L2:  or  L0: split L1, L2; L1: sub; jump L0; L2:
+	pc := c.split()
+	if nullable(sub) {
+		c.plus(sub, greedy)
+	} else {
+		c.node(sub)
+		c.prog[c.emit(inst{op: opJump})].out = pc
+	}
+	c.patchSplit(pc, len(c.prog), greedy)
+}
+
+// plus emits x+ as L0: sub; split L0, L1; L1:
+func (c *compiler) plus(sub *node, greedy bool) {
+	start := len(c.prog)
+	c.node(sub)
+	pc := c.split()
+	c.prog[pc].out, c.prog[pc].arg = start, pc+1
+	if !greedy {
+		c.prog[pc].out, c.prog[pc].arg = pc+1, start
+	}
+}
+
+// optional emits k nested optional copies of the sub expression, split L1, L2; L1: sub; (k-1 copies); L2:
+func (c *compiler) optional(sub *node, k int, greedy bool) {
+	if k == 0 {
+		return
+	}
+	pc := c.split()
+	c.node(sub)
+	c.optional(sub, k-1, greedy)
+	c.patchSplit(pc, len(c.prog), greedy)
+}
+
+// nullable reports whether the node can match the empty sequence
+func nullable(n *node) bool {
+	switch n.kind {
+	case nodeEmpty, nodeBegin, nodeEnd:
+		return true
+	case nodeConcat:
+		for _, sub := range n.subs {
+			if !nullable(sub) {
+				return false
+			}
+		}
+		return true
+	case nodeAlternate:
+		for _, sub := range n.subs {
+			if nullable(sub) {
+				return true
+			}
+		}
+		return false
+	case nodeCapture:
+		return nullable(n.subs[0])
+	case nodeRepeat:
+		return n.min == 0 || nullable(n.subs[0])
+	}
+	return false
+}
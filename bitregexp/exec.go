@@ -0,0 +1,152 @@
+package bitregexp
+
+import (
+	"github.com/yulin-physics/bitop"
+)
+
+// input is a bit sequence read from left to right
+type input interface {
+	len() int
+	bit(i int) uint
+}
+
+// unitInput reads the bits of a Unit
+type unitInput struct {
+	b bitop.Unit
+}
+
+func (in unitInput) len() int {
+	return in.b.Len()
+}
+
+func (in unitInput) bit(i int) uint {
+	return bitop.GetBitAtIndex(in.b, i)
+}
+
+// bytesInput reads the first n bits of packed bytes, most significant bit first
+type bytesInput struct {
+	p []byte
+	n int
+}
+
+func (in bytesInput) len() int {
+	return in.n
+}
+
+func (in bytesInput) bit(i int) uint {
+	return uint(in.p[i/8]>>(7-i%8)) & 1
+}
+
+// stringInput reads a string of 0s and 1s
+type stringInput string
+
+func (in stringInput) len() int {
+	return len(in)
+}
+
+func (in stringInput) bit(i int) uint {
+	return uint(in[i] - '0')
+}
+
+// thread is a position in the program with the capture slots recorded along its path
+type thread struct {
+	pc   int
+	caps []int
+}
+
+// queue is the ordered set of threads for one input position, each instruction appears at most once
+type queue struct {
+	seen    []bool
+	threads []thread
+}
+
+func newQueue(n int) *queue {
+	return &queue{seen: make([]bool, n)}
+}
+
+func (q *queue) clear() {
+	for i := range q.seen {
+		q.seen[i] = false
+	}
+	q.threads = q.threads[:0]
+}
+
+// machine runs the program on an input as a Pike VM, simulating the NFA in time linear in the input
+// Threads are kept in priority order, so the first thread to match gives the leftmost-first match of Perl and Go
+type machine struct {
+	prog  []inst
+	ncap  int
+	in    input
+	clist *queue
+	nlist *queue
+}
+
+// match returns the capture slots of the leftmost-first match starting at or after pos, nil if there is none
+func (m *machine) match(pos int) []int {
+	m.clist.clear()
+	m.nlist.clear()
+	anchored := m.prog[m.prog[0].out].op == opBegin
+	var matched []int
+	for i := pos; ; i++ {
+		if matched == nil && (!anchored || i == 0) {
+			caps := make([]int, 2*m.ncap)
+			for j := range caps {
+				caps[j] = -1
+			}
+			m.add(m.clist, 0, i, caps)
+		}
+		// With no live threads, only a later start position can still match
+		if len(m.clist.threads) == 0 && (matched != nil || anchored && i > 0) {
+			break
+		}
+		for _, t := range m.clist.threads {
+			in := m.prog[t.pc]
+			if in.op == opMatch {
+				matched = t.caps
+				break
+			}
+			if i >= m.in.len() {
+				continue
+			}
+			if in.op == opAny || in.op == opBit && uint(in.arg) == m.in.bit(i) {
+				m.add(m.nlist, in.out, i+1, t.caps)
+			}
+		}
+		if i >= m.in.len() {
+			break
+		}
+		m.clist, m.nlist = m.nlist, m.clist
+		m.nlist.clear()
+	}
+	return matched
+}
+
+// add follows the empty transitions from pc at input position pos, queueing the threads that consume a bit or match
+func (m *machine) add(q *queue, pc, pos int, caps []int) {
+	if q.seen[pc] {
+		return
+	}
+	q.seen[pc] = true
+	switch in := m.prog[pc]; in.op {
+	case opFail:
+	case opJump:
+		m.add(q, in.out, pos, caps)
+	case opSplit:
+		m.add(q, in.out, pos, caps)
+		m.add(q, in.arg, pos, caps)
+	case opSave:
+		saved := append([]int(nil), caps...)
+		saved[in.arg] = pos
+		m.add(q, in.out, pos, saved)
+	case opBegin:
+		if pos == 0 {
+			m.add(q, in.out, pos, caps)
+		}
+	case opEnd:
+		if pos == m.in.len() {
+			m.add(q, in.out, pos, caps)
+		}
+	default:
+		q.threads = append(q.threads, thread{pc: pc, caps: caps})
+	}
+}
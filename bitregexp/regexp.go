@@ -0,0 +1,190 @@
+// Package bitregexp implements regular expressions over bit sequences, whose alphabet is the two bits 0 and 1
+//
+// The syntax is a small subset of Go's regexp:
+//
+//	0 1        a single bit
+//	.          any bit
+//	[01] [^0]  a class of bits
+//	xy  x|y    concatenation and alternation, preferring x
+//	x* x+ x?   zero or more, one or more, zero or one x, greedy
+//	x{m,n}     m to n x, also x{m} and x{m,}, greedy
+//	x*? x+? x?? x{m,n}?  the same, preferring fewer x
+//	(x)        capture group
+//	(?:x)      non-capturing group
+//	^ $        beginning and end of the bit sequence
+//
+// Counts in x{m,n} are at most 1000, and an expression whose repetitions expand to more than 1<<20 instructions
+// is rejected with ErrSyntax, so untrusted expressions cannot exhaust memory.
+//
+// Matching uses a Pike VM over the compiled NFA, taking time linear in the length of the input,
+// and returns the leftmost-first match as Go's regexp does.
+package bitregexp
+
+import (
+	"math/bits"
+
+	"github.com/yulin-physics/bitop"
+)
+
+// Regexp is a compiled regular expression over bits, safe for concurrent use
+type Regexp struct {
+	expr string
+	prog []inst
+	ncap int
+}
+
+// Compile parses the expression and returns a Regexp matching it
+func Compile(expr string) (*Regexp, error) {
+	n, ncap, err := parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &Regexp{expr: expr, prog: compile(n), ncap: ncap + 1}, nil
+}
+
+// MustCompile is like Compile but panics if the expression cannot be parsed
+func MustCompile(expr string) *Regexp {
+	re, err := Compile(expr)
+	if err != nil {
+		panic(err)
+	}
+	return re
+}
+
+// String returns the source expression
+func (re *Regexp) String() string {
+	return re.expr
+}
+
+// NumSubexp returns the number of capture groups
+func (re *Regexp) NumSubexp() int {
+	return re.ncap - 1
+}
+
+// Match reports whether the bits of the unit contain a match
+func (re *Regexp) Match(b bitop.Unit) bool {
+	return re.find(unitInput{b}, 0) != nil
+}
+
+// MatchBytes reports whether the first n bits of p contain a match, most significant bit of p[0] first
+func (re *Regexp) MatchBytes(p []byte, n int) bool {
+	return re.find(bytesIn(p, n), 0) != nil
+}
+
+// MatchString reports whether the string of 0s and 1s contains a match, false if it holds any other character
+func (re *Regexp) MatchString(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '0' && s[i] != '1' {
+			return false
+		}
+	}
+	return re.find(stringInput(s), 0) != nil
+}
+
+// FindIndex returns the bit indexes [start, end) of the leftmost match in the unit, nil if there is none
+func (re *Regexp) FindIndex(b bitop.Unit) []int {
+	return first(re.find(unitInput{b}, 0), 2)
+}
+
+// FindBytesIndex returns the bit indexes [start, end) of the leftmost match in the first n bits of p, nil if there is none
+func (re *Regexp) FindBytesIndex(p []byte, n int) []int {
+	return first(re.find(bytesIn(p, n), 0), 2)
+}
+
+// FindSubmatchIndex returns the bit indexes of the leftmost match in the unit and of each capture group,
+// as pairs [start, end) with -1 for groups that did not take part in the match, nil if there is no match
+func (re *Regexp) FindSubmatchIndex(b bitop.Unit) []int {
+	return re.find(unitInput{b}, 0)
+}
+
+// FindAllIndex returns the bit indexes of up to n successive non-overlapping matches in the unit, all of them if n < 0
+func (re *Regexp) FindAllIndex(b bitop.Unit, n int) [][]int {
+	return re.findAll(unitInput{b}, n)
+}
+
+// FindAllBytesIndex returns the bit indexes of up to n successive non-overlapping matches in the first nbits bits of p
+func (re *Regexp) FindAllBytesIndex(p []byte, nbits int, n int) [][]int {
+	return re.findAll(bytesIn(p, nbits), n)
+}
+
+// ReplaceAll returns the unit with every match replaced by repl
+// The length is capped at the size of a uint, dropping the leftmost bits beyond it
+func (re *Regexp) ReplaceAll(b bitop.Unit, repl bitop.Unit) bitop.Unit {
+	s := re.replaceAll(unitInput{b}, repl).BitString()
+	if s.Len() > bits.UintSize {
+		s = s.TruncateFromLeft(s.Len() - bits.UintSize)
+	}
+	u, _ := s.ToUnit()
+	return u
+}
+
+// ReplaceAllBytes returns the first n bits of p with every match replaced by repl, packed into bytes along with the number of bits
+func (re *Regexp) ReplaceAllBytes(p []byte, n int, repl bitop.Unit) ([]byte, int) {
+	b := re.replaceAll(bytesIn(p, n), repl)
+	return b.Bytes(), b.Len()
+}
+
+// find returns the capture slots of the leftmost match starting at or after pos
+func (re *Regexp) find(in input, pos int) []int {
+	m := &machine{prog: re.prog, ncap: re.ncap, in: in, clist: newQueue(len(re.prog)), nlist: newQueue(len(re.prog))}
+	return m.match(pos)
+}
+
+// findAll returns the bounds of up to n successive matches, an empty match right after a previous match is skipped
+func (re *Regexp) findAll(in input, n int) [][]int {
+	var all [][]int
+	prevEnd := -1
+	for pos := 0; pos <= in.len() && (n < 0 || len(all) < n); {
+		loc := re.find(in, pos)
+		if loc == nil {
+			break
+		}
+		accept := true
+		if loc[1] == loc[0] {
+			if loc[0] == prevEnd {
+				accept = false
+			}
+			pos = loc[1] + 1
+		} else {
+			pos = loc[1]
+		}
+		prevEnd = loc[1]
+		if accept {
+			all = append(all, first(loc, 2))
+		}
+	}
+	return all
+}
+
+// replaceAll writes the input with every match replaced by repl
+func (re *Regexp) replaceAll(in input, repl bitop.Unit) *bitop.Builder {
+	var b bitop.Builder
+	last := 0
+	for _, loc := range re.findAll(in, -1) {
+		for i := last; i < loc[0]; i++ {
+			b.WriteBit(in.bit(i))
+		}
+		b.WriteUnit(repl)
+		last = loc[1]
+	}
+	for i := last; i < in.len(); i++ {
+		b.WriteBit(in.bit(i))
+	}
+	return &b
+}
+
+// bytesIn returns the input of the first n bits of p, n is capped at the number of bits in p
+func bytesIn(p []byte, n int) bytesInput {
+	if n < 0 || n > len(p)*8 {
+		n = len(p) * 8
+	}
+	return bytesInput{p: p, n: n}
+}
+
+// first returns the first n slots, nil if there is no match
+func first(caps []int, n int) []int {
+	if caps == nil {
+		return nil
+	}
+	return caps[:n:n]
+}
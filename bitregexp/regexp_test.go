@@ -0,0 +1,259 @@
+package bitregexp
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/yulin-physics/bitop"
+)
+
+// unit parses a string of 0s and 1s into a Unit
+func unit(s string) bitop.Unit {
+	u, err := bitop.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func TestCompileErrors(t *testing.T) {
+	t.Parallel()
+	for _, expr := range []string{"2", "(01", "01)", "*1", "1**", "[012]", "[01", "1{2,1}", "1{1001}", "1{a}", "1{2",
+		"((0{1000}){1000}){100}", "(0{1000}){1000}(1{1000}){1000}"} {
+		if _, err := Compile(expr); !errors.Is(err, ErrSyntax) {
+			t.Fatalf("[TestCompileErrors][%s]: Got %v, expected %v", expr, err, ErrSyntax)
+		}
+	}
+}
+
+func TestMatchString(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		expr     string
+		s        string
+		expected bool
+	}{
+		{"", "", true},
+		{"1", "000", false},
+		{"10*11", "0100011", true},
+		{"^10*11$", "0100011", false},
+		{"^10*11$", "100011", true},
+		{"^10+1$", "11", false},
+		{"^1(01)?1$", "1011", true},
+		{"^1(01)?1$", "10101", false},
+		{"^.{3}$", "010", true},
+		{"^.{3}$", "0101", false},
+		{"^0{2,}$", "0", false},
+		{"^0{2,}$", "00000", true},
+		{"^(0|11)*$", "0110011", true},
+		{"^(0|11)*$", "010", false},
+		{"^[^0]+$", "111", true},
+		{"^[^01]", "1", false},
+		{"^(?:10){2}$", "1010", true},
+		{"^(?:10){1,2}1$", "101", true},
+		{"1", "1a", false},
+	} {
+		tc := tc
+		t.Run(fmt.Sprintf("%s~%s", tc.expr, tc.s), func(t *testing.T) {
+			t.Parallel()
+			result := MustCompile(tc.expr).MatchString(tc.s)
+			if result != tc.expected {
+				t.Fatalf("[TestMatchString][%s][%s]: Got %v, expected %v", tc.expr, tc.s, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFindIndex(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		expr     string
+		b        bitop.Unit
+		expected []int
+	}{
+		{"framing", "10*11", unit("0110001100"), []int{2, 8}},
+		{"leftmost first", "0|01", unit("1011"), []int{1, 2}},
+		{"greedy", "10*", unit("110001"), []int{0, 1}},
+		{"longest greedy", "0+", unit("1000101"), []int{1, 4}},
+		{"lazy", "10+?", unit("1000"), []int{0, 2}},
+		{"leading zeroes", "^00", unit("0011"), []int{0, 2}},
+		{"end anchor", "1$", unit("1110"), nil},
+		{"empty match", "0*", unit("1"), []int{0, 0}},
+		{"end", "$", unit("0110"), []int{4, 4}},
+		{"end alternative", "$|00", unit("0110"), []int{4, 4}},
+		{"bit before end", "0$", unit("0110"), []int{3, 4}},
+		{"star of nullable", "(0*|1)*", unit("1"), []int{0, 0}},
+		{"star of empty alternative", "(|1)*", unit("11"), []int{0, 0}},
+		{"plus of nullable", "(0?|1)+", unit("11"), []int{0, 0}},
+		{"no match", "111", unit("110110"), nil},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := MustCompile(tc.expr).FindIndex(tc.b)
+			if fmt.Sprint(result) != fmt.Sprint(tc.expected) {
+				t.Fatalf("[TestFindIndex][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFindSubmatchIndex(t *testing.T) {
+	t.Parallel()
+	re := MustCompile("1(0*)(11)?(1)")
+	if re.NumSubexp() != 3 {
+		t.Fatalf("[TestFindSubmatchIndex]: Got %v groups, expected %v", re.NumSubexp(), 3)
+	}
+	for _, tc := range []struct {
+		b        bitop.Unit
+		expected []int
+	}{
+		{unit("0100111"), []int{1, 7, 2, 4, 4, 6, 6, 7}},
+		{unit("10001"), []int{0, 5, 1, 4, -1, -1, 4, 5}},
+		{unit("0000"), nil},
+	} {
+		result := re.FindSubmatchIndex(tc.b)
+		if fmt.Sprint(result) != fmt.Sprint(tc.expected) {
+			t.Fatalf("[TestFindSubmatchIndex][%v]: Got %v, expected %v", tc.b, result, tc.expected)
+		}
+	}
+}
+
+func TestFindAllIndex(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		expr     string
+		b        bitop.Unit
+		n        int
+		expected [][]int
+	}{
+		{"frames", "10*1", unit("1001101"), -1, [][]int{{0, 4}, {4, 7}}},
+		{"limited", "1", unit("10101"), 2, [][]int{{0, 1}, {2, 3}}},
+		{"empty matches", "0*", unit("1001"), -1, [][]int{{0, 0}, {1, 3}, {4, 4}}},
+		{"end", "$", unit("01"), -1, [][]int{{2, 2}}},
+		{"start", "^1", unit("1011"), -1, [][]int{{0, 1}}},
+		{"none", "11", unit("1010"), -1, nil},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := MustCompile(tc.expr).FindAllIndex(tc.b, tc.n)
+			if fmt.Sprint(result) != fmt.Sprint(tc.expected) {
+				t.Fatalf("[TestFindAllIndex][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestBytes(t *testing.T) {
+	t.Parallel()
+	re := MustCompile("10*11")
+	p := []byte{0b00000000, 0b01000110, 0b10000000}
+	if !re.MatchBytes(p, -1) || re.MatchBytes(p, 12) {
+		t.Fatalf("[TestBytes][match]: wrong result on %08b", p)
+	}
+	if result := re.FindBytesIndex(p, 24); fmt.Sprint(result) != "[9 15]" {
+		t.Fatalf("[TestBytes][find]: Got %v, expected %v", result, "[9 15]")
+	}
+	if result := re.FindAllBytesIndex(p, 24, -1); fmt.Sprint(result) != "[[9 15]]" {
+		t.Fatalf("[TestBytes][find all]: Got %v, expected %v", result, "[[9 15]]")
+	}
+	replaced, n := re.ReplaceAllBytes(p, 24, unit("1"))
+	if expected := []byte{0b00000000, 0b01010000, 0b00000000}; n != 19 || string(replaced) != string(expected) {
+		t.Fatalf("[TestBytes][replace]: Got %08b (%d bits), expected %08b (%d bits)", replaced, n, expected, 19)
+	}
+}
+
+func TestReplaceAll(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		expr     string
+		b        bitop.Unit
+		repl     bitop.Unit
+		expected bitop.Unit
+	}{
+		{"shrink", "10*1", unit("0100101"), unit("1"), unit("0101")},
+		{"grow", "1", unit("0101"), unit("11"), unit("011011")},
+		{"leading zeroes", "1", unit("0011"), unit("0"), unit("0000")},
+		{"empty matches", "0*", unit("11"), unit("0"), unit("01010")},
+		{"append at end", "$", unit("01"), unit("11"), unit("0111")},
+		{"end alternative", "$|00", unit("1001"), unit("1"), unit("1111")},
+		{"no match", "11", unit("0101"), unit("0"), unit("0101")},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := MustCompile(tc.expr).ReplaceAll(tc.b, tc.repl)
+			if result != tc.expected {
+				t.Fatalf("[TestReplaceAll][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+// randomExpr returns a random expression over bits that both this package and Go's regexp accept
+func randomExpr(r *rand.Rand, depth int) string {
+	if depth == 0 || r.Intn(3) == 0 {
+		atoms := []string{"0", "1", ".", "[01]", "[^0]", "[^1]", "^", "$", ""}
+		return atoms[r.Intn(len(atoms))]
+	}
+	switch r.Intn(5) {
+	case 0:
+		return randomExpr(r, depth-1) + randomExpr(r, depth-1)
+	case 1:
+		return randomExpr(r, depth-1) + "|" + randomExpr(r, depth-1)
+	case 2:
+		return "(" + randomExpr(r, depth-1) + ")"
+	}
+	quantifiers := []string{"*", "+", "?", "{2}", "{0,2}", "{1,}", "{1,3}"}
+	q := quantifiers[r.Intn(len(quantifiers))]
+	if r.Intn(3) == 0 {
+		q += "?"
+	}
+	if r.Intn(2) == 0 {
+		return "(?:" + randomExpr(r, depth-1) + ")" + q
+	}
+	return "(" + randomExpr(r, depth-1) + ")" + q
+}
+
+func TestCompareWithRegexp(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		expr := randomExpr(r, 4)
+		re, err := Compile(expr)
+		if err != nil {
+			t.Fatalf("[TestCompareWithRegexp][%s]: Got %v, expected %v", expr, err, nil)
+		}
+		goRe := regexp.MustCompile(expr)
+		for j := 0; j < 5; j++ {
+			var sb strings.Builder
+			for k := r.Intn(9); k > 0; k-- {
+				sb.WriteByte("01"[r.Intn(2)])
+			}
+			s := sb.String()
+			b := unit(s)
+			for _, c := range []struct {
+				name     string
+				result   any
+				expected any
+			}{
+				{"FindIndex", re.FindIndex(b), goRe.FindStringIndex(s)},
+				{"FindSubmatchIndex", re.FindSubmatchIndex(b), goRe.FindStringSubmatchIndex(s)},
+				{"FindAllIndex", re.FindAllIndex(b, -1), goRe.FindAllStringIndex(s, -1)},
+				{"ReplaceAll", re.ReplaceAll(b, unit("10")).String(), goRe.ReplaceAllLiteralString(s, "10")},
+			} {
+				if fmt.Sprint(c.result) != fmt.Sprint(c.expected) {
+					t.Fatalf("[TestCompareWithRegexp][%s][%s][%q]: Got %v, expected %v", c.name, expr, s, c.result, c.expected)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package bitregexp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrSyntax is returned by Compile when the expression cannot be parsed
+var ErrSyntax = errors.New("bitregexp: invalid syntax")
+
+const (
+	// maxRepeat is the largest count allowed in a {m,n} repetition
+	maxRepeat = 1000
+	// maxSize is the largest number of instructions an expression may compile to, once repetitions are expanded
+	maxSize = 1 << 20
+)
+
+// nodeKind is the kind of a node in the parsed expression
+type nodeKind int
+
+const (
+	nodeBit nodeKind = iota
+	nodeAny
+	nodeFail
+	nodeEmpty
+	nodeBegin
+	nodeEnd
+	nodeConcat
+	nodeAlternate
+	nodeRepeat
+	nodeCapture
+)
+
+// node is a parsed regular expression
+type node struct {
+	kind     nodeKind
+	bit      uint
+	subs     []*node
+	min, max int // repetition counts, max is -1 when unbounded
+	greedy   bool
+	capture  int
+}
+
+// parser reads an expression by recursive descent
+type parser struct {
+	expr string
+	pos  int
+	ncap int
+}
+
+// parse returns the parsed expression and the number of capture groups, not counting the whole match
+func parse(expr string) (*node, int, error) {
+	p := &parser{expr: expr}
+	n, err := p.alternate()
+	if err != nil {
+		return nil, 0, err
+	}
+	if p.pos < len(expr) {
+		return nil, 0, p.errorf("unexpected %q", expr[p.pos])
+	}
+	if size(n) > maxSize {
+		return nil, 0, p.errorf("expression too large")
+	}
+	return n, p.ncap, nil
+}
+
+// size returns the number of instructions the node compiles to, as emitted by compiler.node
+func size(n *node) int {
+	switch n.kind {
+	case nodeEmpty:
+		return 0
+	case nodeConcat, nodeAlternate:
+		total := 0
+		for _, sub := range n.subs {
+			total += size(sub)
+		}
+		if n.kind == nodeAlternate {
+			total += 2 * (len(n.subs) - 1)
+		}
+		return total
+	case nodeCapture:
+		return size(n.subs[0]) + 2
+	case nodeRepeat:
+		sub := size(n.subs[0])
+		if n.max < 0 && n.min == 0 {
+			return sub + 2
+		}
+		if n.max < 0 {
+			return n.min*sub + 1
+		}
+		return n.min*sub + (n.max-n.min)*(sub+1)
+	}
+	return 1
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at position %d in %q", ErrSyntax, fmt.Sprintf(format, args...), p.pos, p.expr)
+}
+
+func (p *parser) peek() byte {
+	if p.pos < len(p.expr) {
+		return p.expr[p.pos]
+	}
+	return 0
+}
+
+// alternate parses concat ('|' concat)*
+func (p *parser) alternate() (*node, error) {
+	n, err := p.concat()
+	if err != nil {
+		return nil, err
+	}
+	alt := &node{kind: nodeAlternate, subs: []*node{n}}
+	for p.peek() == '|' {
+		p.pos++
+		n, err := p.concat()
+		if err != nil {
+			return nil, err
+		}
+		alt.subs = append(alt.subs, n)
+	}
+	if len(alt.subs) == 1 {
+		return alt.subs[0], nil
+	}
+	return alt, nil
+}
+
+// concat parses a sequence of repeated atoms, up to '|', ')' or the end
+func (p *parser) concat() (*node, error) {
+	cat := &node{kind: nodeConcat}
+	for p.pos < len(p.expr) && p.peek() != '|' && p.peek() != ')' {
+		n, err := p.repeat()
+		if err != nil {
+			return nil, err
+		}
+		cat.subs = append(cat.subs, n)
+	}
+	switch len(cat.subs) {
+	case 0:
+		return &node{kind: nodeEmpty}, nil
+	case 1:
+		return cat.subs[0], nil
+	}
+	return cat, nil
+}
+
+// repeat parses an atom followed by an optional quantifier
+func (p *parser) repeat() (*node, error) {
+	n, err := p.atom()
+	if err != nil {
+		return nil, err
+	}
+	min, max := 0, 0
+	switch p.peek() {
+	case '*':
+		min, max = 0, -1
+		p.pos++
+	case '+':
+		min, max = 1, -1
+		p.pos++
+	case '?':
+		min, max = 0, 1
+		p.pos++
+	case '{':
+		if min, max, err = p.counts(); err != nil {
+			return nil, err
+		}
+	default:
+		return n, nil
+	}
+	greedy := true
+	if p.peek() == '?' {
+		greedy = false
+		p.pos++
+	}
+	if c := p.peek(); c == '*' || c == '+' || c == '?' || c == '{' {
+		return nil, p.errorf("nested repetition operator")
+	}
+	n = &node{kind: nodeRepeat, subs: []*node{n}, min: min, max: max, greedy: greedy}
+	// Checking each repetition keeps nested counts from multiplying beyond the limit before the whole is checked
+	if size(n) > maxSize {
+		return nil, p.errorf("repetition too large")
+	}
+	return n, nil
+}
+
+// counts parses {m}, {m,} or {m,n}
+func (p *parser) counts() (int, int, error) {
+	start := p.pos
+	p.pos++
+	min, ok := p.number()
+	if !ok {
+		return 0, 0, p.errorf("missing repetition count")
+	}
+	max := min
+	if p.peek() == ',' {
+		p.pos++
+		max = -1
+		if p.peek() != '}' {
+			if max, ok = p.number(); !ok {
+				return 0, 0, p.errorf("invalid repetition count")
+			}
+		}
+	}
+	if p.peek() != '}' {
+		return 0, 0, p.errorf("unterminated repetition %q", p.expr[start:p.pos])
+	}
+	p.pos++
+	if min > maxRepeat || max > maxRepeat || max >= 0 && max < min {
+		return 0, 0, p.errorf("invalid repetition %q", p.expr[start:p.pos])
+	}
+	return min, max, nil
+}
+
+// number parses a decimal number
+func (p *parser) number() (int, bool) {
+	start := p.pos
+	for p.pos < len(p.expr) && '0' <= p.peek() && p.peek() <= '9' {
+		p.pos++
+	}
+	n, err := strconv.Atoi(p.expr[start:p.pos])
+	return n, err == nil && n <= maxRepeat
+}
+
+// atom parses a bit, '.', a class, a group or an anchor
+func (p *parser) atom() (*node, error) {
+	c := p.peek()
+	switch c {
+	case '0', '1':
+		p.pos++
+		return &node{kind: nodeBit, bit: uint(c - '0')}, nil
+	case '.':
+		p.pos++
+		return &node{kind: nodeAny}, nil
+	case '^':
+		p.pos++
+		return &node{kind: nodeBegin}, nil
+	case '$':
+		p.pos++
+		return &node{kind: nodeEnd}, nil
+	case '[':
+		return p.class()
+	case '(':
+		p.pos++
+		capture := true
+		if len(p.expr)-p.pos >= 2 && p.expr[p.pos:p.pos+2] == "?:" {
+			capture = false
+			p.pos += 2
+		}
+		var index int
+		if capture {
+			p.ncap++
+			index = p.ncap
+		}
+		n, err := p.alternate()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, p.errorf("missing closing )")
+		}
+		p.pos++
+		if !capture {
+			return n, nil
+		}
+		return &node{kind: nodeCapture, subs: []*node{n}, capture: index}, nil
+	case '*', '+', '?', '{':
+		return nil, p.errorf("missing argument to repetition operator")
+	}
+	return nil, p.errorf("unexpected %q", c)
+}
+
+// class parses [bits] or [^bits] where bits are 0s and 1s
+func (p *parser) class() (*node, error) {
+	p.pos++
+	negate := p.peek() == '^'
+	if negate {
+		p.pos++
+	}
+	var set [2]bool
+	for p.peek() != ']' {
+		switch c := p.peek(); c {
+		case '0', '1':
+			set[c-'0'] = true
+			p.pos++
+		case 0:
+			return nil, p.errorf("missing closing ]")
+		default:
+			return nil, p.errorf("invalid character %q in class", c)
+		}
+	}
+	p.pos++
+	if negate {
+		set[0], set[1] = !set[0], !set[1]
+	}
+	switch {
+	case set[0] && set[1]:
+		return &node{kind: nodeAny}, nil
+	case set[0]:
+		return &node{kind: nodeBit, bit: 0}, nil
+	case set[1]:
+		return &node{kind: nodeBit, bit: 1}, nil
+	}
+	return &node{kind: nodeFail}, nil
+}
@@ -0,0 +1,203 @@
+package bitop
+
+import (
+	"iter"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Bitset is a set of non-negative integers stored one bit per integer, growing as needed
+// The zero value is an empty set ready to use
+type Bitset struct {
+	words []uint64
+}
+
+// NewBitset returns a set holding the given integers
+func NewBitset(elems ...int) *Bitset {
+	s := &Bitset{}
+	for _, e := range elems {
+		s.Add(e)
+	}
+	return s
+}
+
+// Add inserts i into the set, negative integers are ignored
+func (s *Bitset) Add(i int) {
+	if i < 0 {
+		return
+	}
+	for i/64 >= len(s.words) {
+		s.words = append(s.words, 0)
+	}
+	s.words[i/64] |= 1 << uint(i%64)
+}
+
+// Remove deletes i from the set
+func (s *Bitset) Remove(i int) {
+	if i < 0 || i/64 >= len(s.words) {
+		return
+	}
+	s.words[i/64] &^= 1 << uint(i%64)
+}
+
+// Has returns true if i is in the set
+func (s *Bitset) Has(i int) bool {
+	if i < 0 || i/64 >= len(s.words) {
+		return false
+	}
+	return s.words[i/64]>>uint(i%64)&1 == 1
+}
+
+// Len returns the number of integers in the set
+func (s *Bitset) Len() int {
+	n := 0
+	for _, w := range s.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Clone returns a copy of the set
+func (s *Bitset) Clone() *Bitset {
+	return &Bitset{words: append([]uint64(nil), s.words...)}
+}
+
+// Equal returns true if both sets hold the same integers
+func (s *Bitset) Equal(t *Bitset) bool {
+	for i := 0; i < len(s.words) || i < len(t.words); i++ {
+		if s.word(i) != t.word(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a new set of the integers in either set
+func (s *Bitset) Union(t *Bitset) *Bitset {
+	return s.combine(t, func(x, y uint64) uint64 { return x | y })
+}
+
+// Intersect returns a new set of the integers in both sets
+func (s *Bitset) Intersect(t *Bitset) *Bitset {
+	return s.combine(t, func(x, y uint64) uint64 { return x & y })
+}
+
+// Difference returns a new set of the integers in s but not in t
+func (s *Bitset) Difference(t *Bitset) *Bitset {
+	return s.combine(t, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// SymmetricDifference returns a new set of the integers in exactly one of the sets
+func (s *Bitset) SymmetricDifference(t *Bitset) *Bitset {
+	return s.combine(t, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// IsSubset returns true if every integer in s is also in t
+func (s *Bitset) IsSubset(t *Bitset) bool {
+	for i, w := range s.words {
+		if w&^t.word(i) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Min returns the smallest integer in the set, ok is false if the set is empty
+func (s *Bitset) Min() (i int, ok bool) {
+	return s.NextSet(0)
+}
+
+// Max returns the largest integer in the set, ok is false if the set is empty
+func (s *Bitset) Max() (i int, ok bool) {
+	return s.PrevSet(len(s.words)*64 - 1)
+}
+
+// NextSet returns the smallest integer in the set that is at least i, ok is false if there is none
+func (s *Bitset) NextSet(i int) (int, bool) {
+	if i < 0 {
+		i = 0
+	}
+	for w := i / 64; w < len(s.words); w++ {
+		word := s.words[w]
+		if w == i/64 {
+			word &^= 1<<uint(i%64) - 1
+		}
+		if word != 0 {
+			return w*64 + bits.TrailingZeros64(word), true
+		}
+	}
+	return -1, false
+}
+
+// PrevSet returns the largest integer in the set that is at most i, ok is false if there is none
+func (s *Bitset) PrevSet(i int) (int, bool) {
+	if i < 0 {
+		return -1, false
+	}
+	if i >= len(s.words)*64 {
+		i = len(s.words)*64 - 1
+	}
+	for w := i / 64; w >= 0; w-- {
+		word := s.words[w]
+		if w == i/64 {
+			word &= 1<<uint(i%64+1) - 1
+		}
+		if word != 0 {
+			return w*64 + 63 - bits.LeadingZeros64(word), true
+		}
+	}
+	return -1, false
+}
+
+// All returns an iterator over the integers in the set in increasing order
+func (s *Bitset) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for w, word := range s.words {
+			for word != 0 {
+				if !yield(w*64 + bits.TrailingZeros64(word)) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
+
+// String returns the integers of the set in increasing order, e.g. {1 3 5}
+func (s *Bitset) String() string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i := range s.All() {
+		if sb.Len() > 1 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(strconv.Itoa(i))
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// word returns the i-th word of the set, zero past the end
+func (s *Bitset) word(i int) uint64 {
+	if i < len(s.words) {
+		return s.words[i]
+	}
+	return 0
+}
+
+// combine returns a new set applying op to each pair of words
+func (s *Bitset) combine(t *Bitset, op func(x, y uint64) uint64) *Bitset {
+	n := len(s.words)
+	if len(t.words) > n {
+		n = len(t.words)
+	}
+	r := &Bitset{words: make([]uint64, n)}
+	for i := range r.words {
+		r.words[i] = op(s.word(i), t.word(i))
+	}
+	for len(r.words) > 0 && r.words[len(r.words)-1] == 0 {
+		r.words = r.words[:len(r.words)-1]
+	}
+	return r
+}
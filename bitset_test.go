@@ -0,0 +1,119 @@
+package bitop
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBitset(t *testing.T) {
+	t.Parallel()
+	s := NewBitset(3, 64, 200)
+	s.Add(-1)
+	s.Add(3)
+	if s.Len() != 3 || !s.Has(64) || s.Has(65) || s.Has(-1) {
+		t.Fatalf("[TestBitset][add]: Got %v, expected {3 64 200}", s)
+	}
+	s.Remove(64)
+	s.Remove(1000)
+	if s.Len() != 2 || s.Has(64) {
+		t.Fatalf("[TestBitset][remove]: Got %v, expected {3 200}", s)
+	}
+	if s.String() != "{3 200}" {
+		t.Fatalf("[TestBitset][string]: Got %v, expected {3 200}", s)
+	}
+}
+
+func TestBitsetAlgebra(t *testing.T) {
+	t.Parallel()
+	a := NewBitset(1, 2, 3, 100)
+	b := NewBitset(2, 3, 4, 200)
+	for _, tc := range []struct {
+		name     string
+		result   *Bitset
+		expected *Bitset
+	}{
+		{"Union", a.Union(b), NewBitset(1, 2, 3, 4, 100, 200)},
+		{"Intersect", a.Intersect(b), NewBitset(2, 3)},
+		{"Difference", a.Difference(b), NewBitset(1, 100)},
+		{"SymmetricDifference", a.SymmetricDifference(b), NewBitset(1, 4, 100, 200)},
+		{"empty", a.Difference(a), NewBitset()},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if !tc.result.Equal(tc.expected) {
+				t.Fatalf("[TestBitsetAlgebra][%s]: Got %v, expected %v", tc.name, tc.result, tc.expected)
+			}
+		})
+	}
+
+	if !NewBitset(2, 3).IsSubset(a) || a.IsSubset(b) || !NewBitset().IsSubset(b) {
+		t.Fatalf("[TestBitsetAlgebra][IsSubset]: wrong result for %v and %v", a, b)
+	}
+}
+
+func TestBitsetSearch(t *testing.T) {
+	t.Parallel()
+	s := NewBitset(5, 63, 64, 130)
+	for _, tc := range []struct {
+		name     string
+		result   func() (int, bool)
+		expected int
+		ok       bool
+	}{
+		{"Min", s.Min, 5, true},
+		{"Max", s.Max, 130, true},
+		{"Min empty", NewBitset().Min, -1, false},
+		{"Max empty", NewBitset().Max, -1, false},
+		{"NextSet on element", func() (int, bool) { return s.NextSet(63) }, 63, true},
+		{"NextSet across words", func() (int, bool) { return s.NextSet(65) }, 130, true},
+		{"NextSet past end", func() (int, bool) { return s.NextSet(131) }, -1, false},
+		{"PrevSet across words", func() (int, bool) { return s.PrevSet(129) }, 64, true},
+		{"PrevSet past end", func() (int, bool) { return s.PrevSet(1000) }, 130, true},
+		{"PrevSet before start", func() (int, bool) { return s.PrevSet(4) }, -1, false},
+	} {
+		result, ok := tc.result()
+		if result != tc.expected || ok != tc.ok {
+			t.Fatalf("[TestBitsetSearch][%s]: Got %v %v, expected %v %v", tc.name, result, ok, tc.expected, tc.ok)
+		}
+	}
+}
+
+func TestBitsetAll(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(1))
+	model := map[int]bool{}
+	s := &Bitset{}
+	for i := 0; i < 500; i++ {
+		e := rng.Intn(1000)
+		if rng.Intn(3) == 0 {
+			s.Remove(e)
+			delete(model, e)
+		} else {
+			s.Add(e)
+			model[e] = true
+		}
+	}
+
+	prev, n := -1, 0
+	for e := range s.All() {
+		if e <= prev || !model[e] {
+			t.Fatalf("[TestBitsetAll]: Got %v after %v, not in increasing order or not in model", e, prev)
+		}
+		prev = e
+		n++
+	}
+	if n != len(model) || s.Len() != len(model) {
+		t.Fatalf("[TestBitsetAll]: Got %v elements, expected %v", n, len(model))
+	}
+
+	first := 0
+	for range s.All() {
+		if first++; first == 3 {
+			break
+		}
+	}
+	if first != 3 {
+		t.Fatalf("[TestBitsetAll]: Got %v elements before break, expected %v", first, 3)
+	}
+}
@@ -0,0 +1,363 @@
+package bitop
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// BitString is a binary of arbitrary length, the bits are packed from left to right into 64-bit words
+// Bits past leng in the last word are always kept as zero
+type BitString struct {
+	words []uint64
+	leng  int
+}
+
+// NewBitString returns a bit string of the given length with all bits set to zero
+func NewBitString(leng int) BitString {
+	if leng < 0 {
+		leng = 0
+	}
+	return BitString{words: make([]uint64, (leng+63)/64), leng: leng}
+}
+
+// BitStringFromUnit returns a bit string holding the same bits as the unit
+func BitStringFromUnit(b Unit) BitString {
+	var s BitString
+	s.appendBits(uint64(b.value), b.leng)
+	return s
+}
+
+// BitStringFromBytes returns a bit string of the first leng bits of p, most significant bit of p[0] first
+// Give -ve leng to take all bits of p
+func BitStringFromBytes(p []byte, leng int) BitString {
+	if leng < 0 || leng > len(p)*8 {
+		leng = len(p) * 8
+	}
+	var s BitString
+	for i := 0; i < leng; i += 8 {
+		n := 8
+		if leng-i < n {
+			n = leng - i
+		}
+		s.appendBits(uint64(p[i/8]>>(8-n)), n)
+	}
+	return s
+}
+
+// Len returns the number of bits in the bit string
+func (s BitString) Len() int {
+	return s.leng
+}
+
+// Bytes returns the bits packed into bytes, the last byte is padded with zeros on the right
+func (s BitString) Bytes() []byte {
+	p := make([]byte, (s.leng+7)/8)
+	for i := range p {
+		p[i] = byte(s.words[i/8] >> (56 - i%8*8))
+	}
+	return p
+}
+
+// ToUnit returns the bit string as a Unit, ok is false if the bit string does not fit in a uint
+func (s BitString) ToUnit() (b Unit, ok bool) {
+	if s.leng > bits.UintSize {
+		return Unit{}, false
+	}
+	return Unit{value: uint(s.bits(0, s.leng)), leng: s.leng}, true
+}
+
+// String returns the bits as a string of 0s and 1s
+func (s BitString) String() string {
+	var sb strings.Builder
+	sb.Grow(s.leng)
+	for i := 0; i < s.leng; i++ {
+		sb.WriteByte('0' + byte(s.GetBitAtIndex(i)))
+	}
+	return sb.String()
+}
+
+// Equal returns true if both bit strings have the same length and bits
+func (s BitString) Equal(t BitString) bool {
+	return s.leng == t.leng && s.matchAt(0, t)
+}
+
+// Contains returns true if the bit string has at least one section that matches with `sub`
+func (s BitString) Contains(sub BitString) bool {
+	return s.Index(sub) >= 0
+}
+
+// Index returns the first index of the given bit pattern, if no matching found -1 is returned
+func (s BitString) Index(sub BitString) int {
+	for i := 0; i <= s.leng-sub.leng; i++ {
+		if s.matchAt(i, sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexAll returns every index where the given bit pattern matches, in increasing order
+// Matches may share bits if overlapping is true, otherwise the search resumes after the end of each match
+func (s BitString) IndexAll(sub BitString, overlapping bool) []int {
+	var inds []int
+	for i := 0; i <= s.leng-sub.leng; {
+		if !s.matchAt(i, sub) {
+			i++
+			continue
+		}
+		inds = append(inds, i)
+		if overlapping || sub.leng == 0 {
+			i++
+		} else {
+			i += sub.leng
+		}
+	}
+	return inds
+}
+
+// Count returns the number of non-overlapping matches of the bit pattern, an empty pattern matches at every index
+func (s BitString) Count(sub BitString) int {
+	return len(s.IndexAll(sub, false))
+}
+
+// LastIndex returns the last index of the given bit pattern, if no matching found -1 is returned
+func (s BitString) LastIndex(sub BitString) int {
+	for i := s.leng - sub.leng; i >= 0; i-- {
+		if s.matchAt(i, sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// GetBitAtIndex returns the bit at index `ind`, index counting from left to right from zero
+// Index out of range returns zero
+func (s BitString) GetBitAtIndex(ind int) uint {
+	if ind < 0 || ind >= s.leng {
+		return 0
+	}
+	return uint(s.words[ind/64]>>(63-ind%64)) & 1
+}
+
+// SplitAt returns the bit string in two halves at the index specified [0, ind)
+func (s BitString) SplitAt(ind int) []BitString {
+	if ind < 0 {
+		return []BitString{s.slice(0, s.leng)}
+	}
+	if ind > s.leng {
+		ind = s.leng
+	}
+	return []BitString{s.slice(0, ind), s.slice(ind, s.leng)}
+}
+
+// TruncateFromRight returns the bit string with `pos` bits trimmed off from the right
+func (s BitString) TruncateFromRight(pos int) BitString {
+	if pos < 0 {
+		pos = 0
+	}
+	if pos > s.leng {
+		pos = s.leng
+	}
+	return s.slice(0, s.leng-pos)
+}
+
+// ClearFromRight returns the bit string with bits set to zero up to the index from the right, exclusive of the index `ind`
+func (s BitString) ClearFromRight(ind int) BitString {
+	if ind > s.leng {
+		ind = s.leng
+	}
+	c := s.slice(0, s.leng)
+	for i := s.leng - ind; i < s.leng; i++ {
+		c.words[i/64] &^= 1 << (63 - i%64)
+	}
+	return c
+}
+
+// TruncateFromLeft returns the bit string truncated up to the index from the left, exclusive of the index `ind`
+func (s BitString) TruncateFromLeft(ind int) BitString {
+	if ind < 0 {
+		ind = 0
+	}
+	if ind > s.leng {
+		ind = s.leng
+	}
+	return s.slice(ind, s.leng)
+}
+
+// RemoveBit returns the bit string with the bit at index removed, length of the bit string decreases by one
+func (s BitString) RemoveBit(ind int) BitString {
+	if ind < 0 || ind >= s.leng {
+		return s.slice(0, s.leng)
+	}
+	r := s.slice(0, ind)
+	r.appendSlice(s, ind+1, s.leng)
+	return r
+}
+
+// JoinBitStrings returns a single bit string by combining all bit strings together separated by the given separator
+func JoinBitStrings(ss []BitString, sep BitString) BitString {
+	var joined BitString
+	for i, s := range ss {
+		if i > 0 {
+			joined.appendSlice(sep, 0, sep.leng)
+		}
+		joined.appendSlice(s, 0, s.leng)
+	}
+	return joined
+}
+
+// ColumnJoinBitStrings joins the bits in the array at each corresponding bit position to form columns
+// Rows are aligned to the right as in ColumnJoin, missing bits in shorter rows are zero
+func ColumnJoinBitStrings(rows []BitString, colLeng int) []BitString {
+	cols := make([]BitString, colLeng)
+	for i := range cols {
+		for _, row := range rows {
+			cols[i].appendBits(uint64(row.GetBitAtIndex(row.leng-colLeng+i)), 1)
+		}
+	}
+	return cols
+}
+
+// Repeat returns a bit string that is a repetition of the bit pattern for `count` number of repetitions
+func (s BitString) Repeat(count int) BitString {
+	var r BitString
+	for i := 0; i < count; i++ {
+		r.appendSlice(s, 0, s.leng)
+	}
+	return r
+}
+
+// Replace returns a bit string with any old bit pattern replaced by new, up to n times of occurrences
+// An empty old inserts all n copies of new at the front of a non-empty bit string, as Replace does
+func (s BitString) Replace(old, new BitString, n int) BitString {
+	if n < 0 {
+		return s.slice(0, s.leng)
+	}
+
+	var r BitString
+	for i := 0; i < s.leng; {
+		if n > 0 && i+old.leng <= s.leng && s.matchAt(i, old) {
+			r.appendSlice(new, 0, new.leng)
+			n--
+			i += old.leng
+		} else {
+			r.appendBits(uint64(s.GetBitAtIndex(i)), 1)
+			i++
+		}
+	}
+	return r
+}
+
+// FlipAtIndex flips the bit at the specified index in the bit string
+func (s BitString) FlipAtIndex(ind int) BitString {
+	c := s.slice(0, s.leng)
+	if ind >= 0 && ind < s.leng {
+		c.words[ind/64] ^= 1 << (63 - ind%64)
+	}
+	return c
+}
+
+// Flip returns a bit string with all bits flipped
+func (s BitString) Flip() BitString {
+	c := s.slice(0, s.leng)
+	for i := range c.words {
+		c.words[i] = ^c.words[i]
+	}
+	c.clearTail()
+	return c
+}
+
+// Reverse returns a bit string with bits in reversed order
+func (s BitString) Reverse() BitString {
+	r := NewBitString(s.leng)
+	for i := 0; i < s.leng; i++ {
+		if s.GetBitAtIndex(i) == 1 {
+			j := s.leng - i - 1
+			r.words[j/64] |= 1 << (63 - j%64)
+		}
+	}
+	return r
+}
+
+// IsPalindrome checks if the bit string is symmetrical
+func (s BitString) IsPalindrome() bool {
+	for i, j := 0, s.leng-1; i < j; i, j = i+1, j-1 {
+		if s.GetBitAtIndex(i) != s.GetBitAtIndex(j) {
+			return false
+		}
+	}
+	return true
+}
+
+// bits returns n bits, at most 64, starting at index i right aligned in a word
+func (s BitString) bits(i, n int) uint64 {
+	if n == 0 {
+		return 0
+	}
+	w, off := i/64, i%64
+	v := s.words[w] << off
+	if off+n > 64 {
+		v |= s.words[w+1] >> (64 - off)
+	}
+	return v >> (64 - n)
+}
+
+// matchAt reports whether sub matches the bits of s starting at index i
+func (s BitString) matchAt(i int, sub BitString) bool {
+	if i < 0 || i+sub.leng > s.leng {
+		return false
+	}
+	for j := 0; j < sub.leng; j += 64 {
+		n := 64
+		if sub.leng-j < n {
+			n = sub.leng - j
+		}
+		if s.bits(i+j, n) != sub.bits(j, n) {
+			return false
+		}
+	}
+	return true
+}
+
+// slice returns a copy of the bits in [i, j)
+func (s BitString) slice(i, j int) BitString {
+	r := BitString{words: make([]uint64, 0, (j-i+63)/64)}
+	r.appendSlice(s, i, j)
+	return r
+}
+
+// appendSlice appends the bits of t in [i, j)
+func (s *BitString) appendSlice(t BitString, i, j int) {
+	for ; i < j; i += 64 {
+		n := 64
+		if j-i < n {
+			n = j - i
+		}
+		s.appendBits(t.bits(i, n), n)
+	}
+}
+
+// appendBits appends the n lowest bits of v, n at most 64
+func (s *BitString) appendBits(v uint64, n int) {
+	if n <= 0 {
+		return
+	}
+	v <<= 64 - n
+	off := s.leng % 64
+	if off == 0 {
+		s.words = append(s.words, v)
+	} else {
+		s.words[len(s.words)-1] |= v >> off
+		if off+n > 64 {
+			s.words = append(s.words, v<<(64-off))
+		}
+	}
+	s.leng += n
+}
+
+// clearTail resets the unused bits of the last word to zero
+func (s *BitString) clearTail() {
+	if off := s.leng % 64; off != 0 {
+		s.words[len(s.words)-1] &^= ^uint64(0) >> off
+	}
+}
@@ -0,0 +1,524 @@
+package bitop
+
+import (
+	"strings"
+	"testing"
+)
+
+// bs builds a bit string from a string of 0s and 1s
+func bs(s string) BitString {
+	var b BitString
+	for _, c := range s {
+		b.appendBits(uint64(c-'0'), 1)
+	}
+	return b
+}
+
+func TestBitStringFromUnit(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		expected string
+	}{
+		{
+			name:     "empty",
+			b:        NewUnit(0, 0),
+			expected: "",
+		},
+		{
+			name:     "leading zeroes",
+			b:        NewUnit(0b0101, 6),
+			expected: "000101",
+		},
+		{
+			name:     "full word",
+			b:        NewUnit(^uint(0), -1),
+			expected: strings.Repeat("1", 64),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := BitStringFromUnit(tc.b)
+			if result.String() != tc.expected {
+				t.Fatalf("[TestBitStringFromUnit][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			u, ok := result.ToUnit()
+			if !ok || u != tc.b {
+				t.Fatalf("[TestBitStringFromUnit][%s]: Got %v, expected %v", tc.name, u, tc.b)
+			}
+		})
+	}
+}
+
+func TestBitStringBytes(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		p        []byte
+		leng     int
+		expected string
+	}{
+		{
+			name:     "whole bytes",
+			p:        []byte{0xA5, 0x0F},
+			leng:     -1,
+			expected: "1010010100001111",
+		},
+		{
+			name:     "partial byte",
+			p:        []byte{0xA5, 0xF0},
+			leng:     12,
+			expected: "101001011111",
+		},
+		{
+			name:     "many words",
+			p:        []byte{0xFF, 0, 0, 0, 0, 0, 0, 0, 0x80},
+			leng:     65,
+			expected: "11111111" + strings.Repeat("0", 56) + "1",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := BitStringFromBytes(tc.p, tc.leng)
+			if result.String() != tc.expected {
+				t.Fatalf("[TestBitStringBytes][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			back := BitStringFromBytes(result.Bytes(), result.Len())
+			if !back.Equal(result) {
+				t.Fatalf("[TestBitStringBytes][%s]: Got %v, expected %v", tc.name, back, result)
+			}
+		})
+	}
+}
+
+func TestBitStringContains(t *testing.T) {
+	t.Parallel()
+	long := strings.Repeat("0", 100) + "1011" + strings.Repeat("0", 100)
+	for _, tc := range []struct {
+		name     string
+		b        BitString
+		sub      BitString
+		expected bool
+	}{
+		{
+			name:     "ones",
+			b:        bs("111111"),
+			sub:      bs("11"),
+			expected: true,
+		},
+		{
+			name:     "zeroes",
+			b:        bs("000000"),
+			sub:      bs("11"),
+			expected: false,
+		},
+		{
+			name:     "across words",
+			b:        bs(long),
+			sub:      bs("01011"),
+			expected: true,
+		},
+		{
+			name:     "longer sub",
+			b:        bs("1011"),
+			sub:      bs("10110"),
+			expected: false,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := tc.b.Contains(tc.sub)
+			if result != tc.expected {
+				t.Fatalf("[TestBitStringContains][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestBitStringLastIndex(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        BitString
+		sub      BitString
+		expected int
+	}{
+		{
+			name:     "ones",
+			b:        bs("111111"),
+			sub:      bs("11"),
+			expected: 4,
+		},
+		{
+			name:     "no match",
+			b:        bs("000000"),
+			sub:      bs("11"),
+			expected: -1,
+		},
+		{
+			name:     "bit zero",
+			b:        bs("110110"),
+			sub:      bs("0"),
+			expected: 5,
+		},
+		{
+			name:     "across words",
+			b:        bs(strings.Repeat("10", 70)),
+			sub:      bs("0101"),
+			expected: 135,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := tc.b.LastIndex(tc.sub)
+			if result != tc.expected {
+				t.Fatalf("[TestBitStringLastIndex][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestBitStringIndexAll(t *testing.T) {
+	t.Parallel()
+	b := bs(strings.Repeat("0", 62) + "1111" + strings.Repeat("0", 10))
+	if result := b.Index(bs("11")); result != 62 {
+		t.Fatalf("[TestBitStringIndexAll][index]: Got %v, expected %v", result, 62)
+	}
+	if result := b.Count(bs("11")); result != 2 {
+		t.Fatalf("[TestBitStringIndexAll][count]: Got %v, expected %v", result, 2)
+	}
+	result := b.IndexAll(bs("11"), true)
+	expected := []int{62, 63, 64}
+	if len(result) != len(expected) {
+		t.Fatalf("[TestBitStringIndexAll][overlapping]: Got %v, expected %v", result, expected)
+	}
+	for i, r := range result {
+		if r != expected[i] {
+			t.Fatalf("[TestBitStringIndexAll][overlapping]: Got %v, expected %v", result, expected)
+		}
+	}
+}
+
+func TestBitStringSplitAt(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        BitString
+		index    int
+		expected []string
+	}{
+		{
+			name:     "leading zeroes",
+			b:        bs("000101"),
+			index:    2,
+			expected: []string{"00", "0101"},
+		},
+		{
+			name:     "at start",
+			b:        bs("0101"),
+			index:    0,
+			expected: []string{"", "0101"},
+		},
+		{
+			name:     "past end",
+			b:        bs("0101"),
+			index:    9,
+			expected: []string{"0101", ""},
+		},
+		{
+			name:     "across words",
+			b:        bs(strings.Repeat("1", 70) + strings.Repeat("0", 30)),
+			index:    70,
+			expected: []string{strings.Repeat("1", 70), strings.Repeat("0", 30)},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := tc.b.SplitAt(tc.index)
+			for i, r := range result {
+				if r.String() != tc.expected[i] {
+					t.Fatalf("[TestBitStringSplitAt][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestBitStringTruncate(t *testing.T) {
+	t.Parallel()
+	b := bs("0101100111")
+	if result := b.TruncateFromLeft(3); result.String() != "1100111" {
+		t.Fatalf("[TestBitStringTruncate][left]: Got %v, expected %v", result, "1100111")
+	}
+	if result := b.TruncateFromRight(3); result.String() != "0101100" {
+		t.Fatalf("[TestBitStringTruncate][right]: Got %v, expected %v", result, "0101100")
+	}
+	if result := b.ClearFromRight(3); result.String() != "0101100000" {
+		t.Fatalf("[TestBitStringTruncate][clear]: Got %v, expected %v", result, "0101100000")
+	}
+	if result := b.RemoveBit(1); result.String() != "001100111" {
+		t.Fatalf("[TestBitStringTruncate][remove]: Got %v, expected %v", result, "001100111")
+	}
+}
+
+func TestJoinBitStrings(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		ss       []BitString
+		sep      BitString
+		expected string
+	}{
+		{
+			name:     "no separator",
+			ss:       []BitString{bs("00"), bs("000")},
+			sep:      bs(""),
+			expected: "00000",
+		},
+		{
+			name:     "multiple separator",
+			ss:       []BitString{bs("1011"), bs("101"), bs("111"), bs("0000")},
+			sep:      bs("0"),
+			expected: "10110101011100000",
+		},
+		{
+			name:     "beyond 64 bits",
+			ss:       []BitString{bs(strings.Repeat("1", 40)), bs(strings.Repeat("1", 40))},
+			sep:      bs("00"),
+			expected: strings.Repeat("1", 40) + "00" + strings.Repeat("1", 40),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := JoinBitStrings(tc.ss, tc.sep)
+			if result.String() != tc.expected {
+				t.Fatalf("[TestJoinBitStrings][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestColumnJoinBitStrings(t *testing.T) {
+	t.Parallel()
+	rows := []BitString{bs("1010"), bs("010"), bs("110"), bs("01011"), bs("1100")}
+	expected := []string{"10011", "00101", "11110", "00010"}
+	result := ColumnJoinBitStrings(rows, 4)
+	for i, r := range result {
+		if r.String() != expected[i] {
+			t.Fatalf("[TestColumnJoinBitStrings]: Got %v, expected %v", result, expected)
+		}
+	}
+}
+
+func TestBitStringRepeat(t *testing.T) {
+	t.Parallel()
+	result := bs("01").Repeat(50)
+	if expected := strings.Repeat("01", 50); result.String() != expected {
+		t.Fatalf("[TestBitStringRepeat]: Got %v, expected %v", result, expected)
+	}
+}
+
+func TestBitStringReplace(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        BitString
+		old      BitString
+		new      BitString
+		n        int
+		expected string
+	}{
+		{
+			name:     "ones",
+			b:        bs("111111"),
+			old:      bs("1"),
+			new:      bs(""),
+			n:        2,
+			expected: "1111",
+		},
+		{
+			name:     "zeros",
+			b:        bs("000000"),
+			old:      bs("0"),
+			new:      bs("1"),
+			n:        2,
+			expected: "110000",
+		},
+		{
+			name:     "no match",
+			b:        bs("000000"),
+			old:      bs("1"),
+			new:      bs("0"),
+			n:        2,
+			expected: "000000",
+		},
+		{
+			name:     "complex match",
+			b:        bs("1010101"),
+			old:      bs("101"),
+			new:      bs("1"),
+			n:        3,
+			expected: "101",
+		},
+		{
+			name:     "growing",
+			b:        bs(strings.Repeat("1", 60)),
+			old:      bs("1"),
+			new:      bs("10"),
+			n:        60,
+			expected: strings.Repeat("10", 60),
+		},
+		{
+			name:     "empty old",
+			b:        bs("101"),
+			old:      bs(""),
+			new:      bs("11"),
+			n:        2,
+			expected: "1111101",
+		},
+		{
+			name:     "empty old inserts at front",
+			b:        bs("101"),
+			old:      bs(""),
+			new:      bs("11"),
+			n:        10,
+			expected: strings.Repeat("11", 10) + "101",
+		},
+		{
+			name:     "empty old on empty bit string",
+			b:        bs(""),
+			old:      bs(""),
+			new:      bs("11"),
+			n:        2,
+			expected: "",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := tc.b.Replace(tc.old, tc.new, tc.n)
+			if result.String() != tc.expected {
+				t.Fatalf("[TestBitStringReplace][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestBitStringFlip(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        BitString
+		expected string
+	}{
+		{
+			name:     "zeroes",
+			b:        bs("000000"),
+			expected: "111111",
+		},
+		{
+			name:     "mixed",
+			b:        bs("01100101"),
+			expected: "10011010",
+		},
+		{
+			name:     "beyond 64 bits",
+			b:        bs(strings.Repeat("0", 70)),
+			expected: strings.Repeat("1", 70),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := tc.b.Flip()
+			if result.String() != tc.expected {
+				t.Fatalf("[TestBitStringFlip][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			if !result.Flip().Equal(tc.b) {
+				t.Fatalf("[TestBitStringFlip][%s]: Got %v, expected %v", tc.name, result.Flip(), tc.b)
+			}
+		})
+	}
+}
+
+func TestBitStringFlipAtIndex(t *testing.T) {
+	t.Parallel()
+	b := bs(strings.Repeat("0", 80))
+	result := b.FlipAtIndex(70)
+	if expected := strings.Repeat("0", 70) + "1" + strings.Repeat("0", 9); result.String() != expected {
+		t.Fatalf("[TestBitStringFlipAtIndex]: Got %v, expected %v", result, expected)
+	}
+	if b.GetBitAtIndex(70) != 0 {
+		t.Fatalf("[TestBitStringFlipAtIndex]: receiver modified")
+	}
+}
+
+func TestBitStringReverse(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        BitString
+		expected string
+	}{
+		{
+			name:     "mixed",
+			b:        bs("01100101"),
+			expected: "10100110",
+		},
+		{
+			name:     "beyond 64 bits",
+			b:        bs("1" + strings.Repeat("0", 69)),
+			expected: strings.Repeat("0", 69) + "1",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := tc.b.Reverse()
+			if result.String() != tc.expected {
+				t.Fatalf("[TestBitStringReverse][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestBitStringIsPalindrome(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        BitString
+		expected bool
+	}{
+		{
+			name:     "zeroes",
+			b:        bs("0000"),
+			expected: true,
+		},
+		{
+			name:     "010101",
+			b:        bs("010101"),
+			expected: false,
+		},
+		{
+			name:     "beyond 64 bits",
+			b:        bs("1" + strings.Repeat("0", 99) + "1"),
+			expected: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := tc.b.IsPalindrome()
+			if result != tc.expected {
+				t.Fatalf("[TestBitStringIsPalindrome][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
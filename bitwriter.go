@@ -0,0 +1,154 @@
+package bitop
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrUnaligned is returned by Flush under PadStrict when the bits written do not end on a byte boundary
+var ErrUnaligned = errors.New("bitop: bit stream not byte aligned")
+
+// Padding decides how BitWriter completes the last partial byte on Flush
+type Padding int
+
+const (
+	// PadZeros fills the rest of the last byte with zeros
+	PadZeros Padding = iota
+	// PadOnes fills the rest of the last byte with ones
+	PadOnes
+	// PadStrict refuses to pad, Flush returns ErrUnaligned while a partial byte is pending
+	PadStrict
+)
+
+// BitWriter writes a stream bit by bit from left to right, starting from the most significant bit of each byte
+// Complete bytes are buffered, call Flush after the last write to pad and send them to the underlying writer
+type BitWriter struct {
+	w     io.Writer
+	pad   Padding
+	buf   []byte
+	cur   byte
+	nbits int
+	off   int64
+	err   error
+}
+
+// NewBitWriter returns a new BitWriter writing to w, completing the last byte with the given padding
+func NewBitWriter(w io.Writer, pad Padding) *BitWriter {
+	return &BitWriter{w: w, pad: pad, buf: make([]byte, 0, 512)}
+}
+
+// WriteBit writes a single bit, any value other than zero is written as one
+func (bw *BitWriter) WriteBit(bit uint) error {
+	if bit != 0 {
+		bit = 1
+	}
+	return bw.writeBits(uint64(bit), 1)
+}
+
+// WriteBits writes the n lowest bits of v, n more than 64 returns ErrLengthOverflow without writing anything
+func (bw *BitWriter) WriteBits(v uint, n int) error {
+	if n > 64 {
+		return fmt.Errorf("%w: %d bits", ErrLengthOverflow, n)
+	}
+	return bw.writeBits(uint64(v), n)
+}
+
+// WriteUnit writes all bits of the unit, leading zeros included
+func (bw *BitWriter) WriteUnit(u Unit) error {
+	return bw.writeBits(uint64(u.value), u.leng)
+}
+
+// WriteBitString writes all bits of the bit string
+func (bw *BitWriter) WriteBitString(s BitString) error {
+	for i := 0; i < s.leng; i += 64 {
+		n := 64
+		if s.leng-i < n {
+			n = s.leng - i
+		}
+		if err := bw.writeBits(s.bits(i, n), n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write writes all bytes of p, which need not start on a byte boundary
+func (bw *BitWriter) Write(p []byte) (int, error) {
+	for i, b := range p {
+		if err := bw.writeBits(uint64(b), 8); err != nil {
+			return i, err
+		}
+	}
+	return len(p), nil
+}
+
+// Offset returns the number of bits written so far, padding included
+func (bw *BitWriter) Offset() int64 {
+	return bw.off
+}
+
+// Flush pads the last partial byte according to the padding policy and writes all buffered bytes
+func (bw *BitWriter) Flush() error {
+	if bw.nbits > 0 {
+		switch bw.pad {
+		case PadZeros:
+			bw.writeBits(0, 8-bw.nbits)
+		case PadOnes:
+			bw.writeBits(0xFF, 8-bw.nbits)
+		default:
+			if err := bw.flushBuffer(); err != nil {
+				return err
+			}
+			return ErrUnaligned
+		}
+	}
+	return bw.flushBuffer()
+}
+
+// writeBits writes the n lowest bits of v, n at most 64
+func (bw *BitWriter) writeBits(v uint64, n int) error {
+	if bw.err != nil {
+		return bw.err
+	}
+	for n > 0 {
+		k := 8 - bw.nbits
+		if k > n {
+			k = n
+		}
+		n -= k
+		bw.cur = bw.cur<<k | byte(v>>n)&(1<<k-1)
+		bw.nbits += k
+		bw.off += int64(k)
+		if bw.nbits == 8 {
+			bw.buf = append(bw.buf, bw.cur)
+			bw.cur, bw.nbits = 0, 0
+			if len(bw.buf) == cap(bw.buf) {
+				if err := bw.flushBuffer(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// flushBuffer writes the buffered complete bytes to the underlying writer
+func (bw *BitWriter) flushBuffer() error {
+	if bw.err != nil {
+		return bw.err
+	}
+	if len(bw.buf) == 0 {
+		return nil
+	}
+	n, err := bw.w.Write(bw.buf)
+	if err == nil && n < len(bw.buf) {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		bw.err = err
+		return err
+	}
+	bw.buf = bw.buf[:0]
+	return nil
+}
@@ -0,0 +1,130 @@
+package bitop
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestBitWriter(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		pad      Padding
+		units    []Unit
+		expected []byte
+		err      error
+	}{
+		{
+			name:     "aligned",
+			pad:      PadStrict,
+			units:    []Unit{NewUnit(0b101, 3), NewUnit(0b10011, 5), NewUnit(0b0, 1), NewUnit(0b1010111, 7)},
+			expected: []byte{0b10110011, 0b01010111},
+		},
+		{
+			name:     "pad zeros",
+			pad:      PadZeros,
+			units:    []Unit{NewUnit(0b1, 1), NewUnit(0b0, 7), NewUnit(0b11, 3)},
+			expected: []byte{0b10000000, 0b01100000},
+		},
+		{
+			name:     "pad ones",
+			pad:      PadOnes,
+			units:    []Unit{NewUnit(0b00, 2)},
+			expected: []byte{0b00111111},
+		},
+		{
+			name:     "strict",
+			pad:      PadStrict,
+			units:    []Unit{NewUnit(0xAB, 8), NewUnit(0b00, 2)},
+			expected: []byte{0xAB},
+			err:      ErrUnaligned,
+		},
+		{
+			name:     "full word",
+			pad:      PadZeros,
+			units:    []Unit{NewUnit(0b1111, 4), NewUnit(0xFFFFFFFFFFFFFFF0, 64)},
+			expected: []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00},
+		},
+		{
+			name:  "empty",
+			pad:   PadStrict,
+			units: nil,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			bw := NewBitWriter(&buf, tc.pad)
+			for _, u := range tc.units {
+				if err := bw.WriteUnit(u); err != nil {
+					t.Fatalf("[TestBitWriter][%s]: Got %v writing %v", tc.name, err, u)
+				}
+			}
+			err := bw.Flush()
+			if err != tc.err || !bytes.Equal(buf.Bytes(), tc.expected) {
+				t.Fatalf("[TestBitWriter][%s]: Got %08b %v, expected %08b %v", tc.name, buf.Bytes(), err, tc.expected, tc.err)
+			}
+		})
+	}
+}
+
+func TestBitWriterRoundTrip(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	bw := NewBitWriter(&buf, PadZeros)
+	var b Builder
+	for i := 0; i < 1000; i++ {
+		u := NewUnit(uint(i*7919), i%13)
+		bw.WriteUnit(u)
+		b.WriteUnit(u)
+	}
+	if err := bw.Flush(); err != nil {
+		t.Fatalf("[TestBitWriterRoundTrip]: Got %v, expected nil", err)
+	}
+	if bw.Offset() != int64(len(buf.Bytes())*8) {
+		t.Fatalf("[TestBitWriterRoundTrip]: Got offset %v, expected %v", bw.Offset(), len(buf.Bytes())*8)
+	}
+	if !bytes.Equal(buf.Bytes(), b.Bytes()) {
+		t.Fatalf("[TestBitWriterRoundTrip]: Got %08b, expected %08b", buf.Bytes(), b.Bytes())
+	}
+}
+
+func TestBitWriterWriteBitsOverflow(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	bw := NewBitWriter(&buf, PadZeros)
+	if err := bw.WriteBits(1, 72); !errors.Is(err, ErrLengthOverflow) || bw.Offset() != 0 {
+		t.Fatalf("[TestBitWriterWriteBitsOverflow]: Got %v at %v, expected %v at %v", err, bw.Offset(), ErrLengthOverflow, 0)
+	}
+	if err := bw.WriteBits(^uint(0), 64); err != nil || bw.Offset() != 64 {
+		t.Fatalf("[TestBitWriterWriteBitsOverflow][64 bits]: Got %v at %v, expected %v at %v", err, bw.Offset(), nil, 64)
+	}
+	if err := bw.WriteBit(1); err != nil {
+		t.Fatalf("[TestBitWriterWriteBitsOverflow][not sticky]: Got %v, expected %v", err, nil)
+	}
+}
+
+// shortWriter accepts at most one byte per write
+type shortWriter struct{}
+
+func (shortWriter) Write(p []byte) (int, error) {
+	if len(p) > 1 {
+		return 1, nil
+	}
+	return len(p), nil
+}
+
+func TestBitWriterError(t *testing.T) {
+	t.Parallel()
+	bw := NewBitWriter(shortWriter{}, PadZeros)
+	bw.WriteBits(0xFFF, 12)
+	if err := bw.Flush(); !errors.Is(err, io.ErrShortWrite) {
+		t.Fatalf("[TestBitWriterError]: Got %v, expected %v", err, io.ErrShortWrite)
+	}
+	if err := bw.WriteBit(1); !errors.Is(err, io.ErrShortWrite) {
+		t.Fatalf("[TestBitWriterError]: Got %v, expected sticky %v", err, io.ErrShortWrite)
+	}
+}
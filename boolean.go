@@ -0,0 +1,80 @@
+package bitop
+
+import (
+	"fmt"
+)
+
+// Alignment decides how the bits of two units of different lengths are lined up by the boolean operators
+type Alignment int
+
+const (
+	// AlignRight lines up the least significant bits, the shorter unit is padded with zeros on the left
+	AlignRight Alignment = iota
+	// AlignLeft lines up the most significant bits, the shorter unit is padded with zeros on the right
+	AlignLeft
+	// AlignStrict requires both units to be of the same length, otherwise ErrLengthMismatch is returned
+	AlignStrict
+)
+
+// And returns the bitwise AND of the units, the result is as long as the longer unit
+func And(a, b Unit, align Alignment) (Unit, error) {
+	return combine(a, b, align, func(x, y uint) uint { return x & y })
+}
+
+// Or returns the bitwise OR of the units, the result is as long as the longer unit
+func Or(a, b Unit, align Alignment) (Unit, error) {
+	return combine(a, b, align, func(x, y uint) uint { return x | y })
+}
+
+// Xor returns the bitwise XOR of the units, the result is as long as the longer unit
+func Xor(a, b Unit, align Alignment) (Unit, error) {
+	return combine(a, b, align, func(x, y uint) uint { return x ^ y })
+}
+
+// AndNot returns the bits of `a` cleared where `b` is set, the result is as long as the longer unit
+func AndNot(a, b Unit, align Alignment) (Unit, error) {
+	return combine(a, b, align, func(x, y uint) uint { return x &^ y })
+}
+
+// Nand returns the bitwise NAND of the units, the result is as long as the longer unit
+func Nand(a, b Unit, align Alignment) (Unit, error) {
+	return combine(a, b, align, func(x, y uint) uint { return ^(x & y) })
+}
+
+// Nor returns the bitwise NOR of the units, the result is as long as the longer unit
+func Nor(a, b Unit, align Alignment) (Unit, error) {
+	return combine(a, b, align, func(x, y uint) uint { return ^(x | y) })
+}
+
+// Xnor returns the bitwise XNOR of the units, the result is as long as the longer unit
+func Xnor(a, b Unit, align Alignment) (Unit, error) {
+	return combine(a, b, align, func(x, y uint) uint { return ^(x ^ y) })
+}
+
+// combine aligns both units to the longer length and applies op, keeping only the bits within the length
+func combine(a, b Unit, align Alignment, op func(x, y uint) uint) (Unit, error) {
+	if err := Validate(a); err != nil {
+		return Unit{}, err
+	}
+	if err := Validate(b); err != nil {
+		return Unit{}, err
+	}
+
+	leng := a.leng
+	if b.leng > leng {
+		leng = b.leng
+	}
+	switch align {
+	case AlignRight:
+	case AlignLeft:
+		a.value <<= uint(leng - a.leng)
+		b.value <<= uint(leng - b.leng)
+	case AlignStrict:
+		if a.leng != b.leng {
+			return Unit{}, fmt.Errorf("%w: %d and %d bits", ErrLengthMismatch, a.leng, b.leng)
+		}
+	default:
+		return Unit{}, fmt.Errorf("bitop: unknown alignment %d", align)
+	}
+	return Unit{value: op(a.value, b.value) & mask(leng), leng: leng}, nil
+}
@@ -0,0 +1,46 @@
+package bitop
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBooleanOperators(t *testing.T) {
+	t.Parallel()
+	mask := NewUnit(0b10110, 5)
+	reg := NewUnit(0b01100101, 8)
+	for _, tc := range []struct {
+		name     string
+		op       func(a, b Unit, align Alignment) (Unit, error)
+		a        Unit
+		b        Unit
+		align    Alignment
+		expected Unit
+		err      error
+	}{
+		{"And right", And, mask, reg, AlignRight, NewUnit(0b00000100, 8), nil},
+		{"And left", And, mask, reg, AlignLeft, NewUnit(0b00100000, 8), nil},
+		{"And strict", And, mask, reg, AlignStrict, Unit{}, ErrLengthMismatch},
+		{"Or right", Or, mask, reg, AlignRight, NewUnit(0b01110111, 8), nil},
+		{"Or left", Or, mask, reg, AlignLeft, NewUnit(0b11110101, 8), nil},
+		{"Xor right", Xor, mask, reg, AlignRight, NewUnit(0b01110011, 8), nil},
+		{"AndNot right", AndNot, reg, mask, AlignRight, NewUnit(0b01100001, 8), nil},
+		{"AndNot left", AndNot, mask, reg, AlignLeft, NewUnit(0b10010000, 8), nil},
+		{"Nand strict", Nand, NewUnit(0b1100, 4), NewUnit(0b1010, 4), AlignStrict, NewUnit(0b0111, 4), nil},
+		{"Nor strict", Nor, NewUnit(0b1100, 4), NewUnit(0b1010, 4), AlignStrict, NewUnit(0b0001, 4), nil},
+		{"Xnor strict", Xnor, NewUnit(0b1100, 4), NewUnit(0b1010, 4), AlignStrict, NewUnit(0b1001, 4), nil},
+		{"Nor right pads with zeros", Nor, NewUnit(0b1, 1), NewUnit(0b000, 3), AlignRight, NewUnit(0b110, 3), nil},
+		{"full word", Xnor, NewUnit(0, 64), NewUnit(0, 64), AlignStrict, NewUnit(^uint(0), 64), nil},
+		{"empty", And, NewUnit(0, 0), NewUnit(0, 0), AlignStrict, NewUnit(0, 0), nil},
+		{"invalid unit", Or, NewUnit(0b111, 2), reg, AlignRight, Unit{}, ErrLengthOverflow},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := tc.op(tc.a, tc.b, tc.align)
+			if result != tc.expected || !errors.Is(err, tc.err) {
+				t.Fatalf("[TestBooleanOperators][%s]: Got %v %v, expected %v %v", tc.name, result, err, tc.expected, tc.err)
+			}
+		})
+	}
+}
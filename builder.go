@@ -0,0 +1,75 @@
+package bitop
+
+// Builder is used to efficiently build a binary bit by bit, the zero value is ready to use
+// Unlike chaining Join and Repeat, the length of the binary is tracked and may grow past the size of a uint
+type Builder struct {
+	buf BitString
+}
+
+// Len returns the number of bits written so far
+func (b *Builder) Len() int {
+	return b.buf.leng
+}
+
+// Grow reserves space for another n bits
+func (b *Builder) Grow(n int) {
+	need := (b.buf.leng + n + 63) / 64
+	if need > cap(b.buf.words) {
+		words := make([]uint64, len(b.buf.words), need)
+		copy(words, b.buf.words)
+		b.buf.words = words
+	}
+}
+
+// Reset empties the builder
+func (b *Builder) Reset() {
+	b.buf = BitString{}
+}
+
+// WriteBit appends a single bit, any value other than zero is written as one
+func (b *Builder) WriteBit(bit uint) {
+	if bit != 0 {
+		bit = 1
+	}
+	b.buf.appendBits(uint64(bit), 1)
+}
+
+// WriteBits appends the n lowest bits of v, n more than 64 appends leading zeros before them
+func (b *Builder) WriteBits(v uint, n int) {
+	for n > 64 {
+		k := min(n-64, 64)
+		b.buf.appendBits(0, k)
+		n -= k
+	}
+	b.buf.appendBits(uint64(v), n)
+}
+
+// WriteUnit appends all bits of the unit, leading zeros included
+func (b *Builder) WriteUnit(u Unit) {
+	b.buf.appendBits(uint64(u.value), u.leng)
+}
+
+// WriteBitString appends all bits of the bit string
+func (b *Builder) WriteBitString(s BitString) {
+	b.buf.appendSlice(s, 0, s.leng)
+}
+
+// Unit returns the bits written as a Unit, ok is false if they do not fit in a uint
+func (b *Builder) Unit() (u Unit, ok bool) {
+	return b.buf.ToUnit()
+}
+
+// BitString returns a copy of the bits written
+func (b *Builder) BitString() BitString {
+	return b.buf.slice(0, b.buf.leng)
+}
+
+// Bytes returns the bits written packed into bytes, the last byte is padded with zeros on the right
+func (b *Builder) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+// String returns the bits written as a string of 0s and 1s
+func (b *Builder) String() string {
+	return b.buf.String()
+}
@@ -0,0 +1,116 @@
+package bitop
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		build    func(b *Builder)
+		expected string
+	}{
+		{
+			name:     "empty",
+			build:    func(b *Builder) {},
+			expected: "",
+		},
+		{
+			name: "bits",
+			build: func(b *Builder) {
+				b.WriteBit(0)
+				b.WriteBit(1)
+				b.WriteBit(7)
+			},
+			expected: "011",
+		},
+		{
+			name: "leading zeroes",
+			build: func(b *Builder) {
+				b.WriteUnit(NewUnit(0b01, 4))
+				b.WriteBits(0b1, 3)
+			},
+			expected: "0001001",
+		},
+		{
+			name: "beyond 64 bits",
+			build: func(b *Builder) {
+				for i := 0; i < 10; i++ {
+					b.WriteUnit(NewUnit(0b1010101, -1))
+				}
+			},
+			expected: strings.Repeat("1010101", 10),
+		},
+		{
+			name: "reset",
+			build: func(b *Builder) {
+				b.WriteBits(0b1111, 4)
+				b.Reset()
+				b.WriteBits(0b10, 2)
+			},
+			expected: "10",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var b Builder
+			tc.build(&b)
+			if b.String() != tc.expected || b.Len() != len(tc.expected) {
+				t.Fatalf("[TestBuilder][%s]: Got %v, expected %v", tc.name, b.String(), tc.expected)
+			}
+		})
+	}
+}
+
+func TestBuilderAccessors(t *testing.T) {
+	t.Parallel()
+	var b Builder
+	b.WriteUnit(NewUnit(0b1010, 4))
+	b.WriteUnit(NewUnit(0b0101, 6))
+	u, ok := b.Unit()
+	if !ok || u != NewUnit(0b1010000101, 10) {
+		t.Fatalf("[TestBuilderAccessors][unit]: Got %v, expected %v", u, NewUnit(0b1010000101, 10))
+	}
+	if result := b.Bytes(); !bytes.Equal(result, []byte{0b10100001, 0b01000000}) {
+		t.Fatalf("[TestBuilderAccessors][bytes]: Got %08b, expected %08b", result, []byte{0b10100001, 0b01000000})
+	}
+
+	s := b.BitString()
+	b.WriteBit(1)
+	if s.String() != "1010000101" {
+		t.Fatalf("[TestBuilderAccessors][bit string]: Got %v, expected %v", s, "1010000101")
+	}
+
+	b.WriteBits(^uint(0), 64)
+	if _, ok := b.Unit(); ok {
+		t.Fatalf("[TestBuilderAccessors][overflow]: Got ok, expected not ok for %d bits", b.Len())
+	}
+}
+
+func TestBuilderWriteBitsWide(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		v        uint
+		n        int
+		expected string
+	}{
+		{"full word", 1, 64, strings.Repeat("0", 63) + "1"},
+		{"leading zeros", 1, 72, strings.Repeat("0", 71) + "1"},
+		{"several words", ^uint(0), 200, strings.Repeat("0", 136) + strings.Repeat("1", 64)},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var b Builder
+			b.WriteBits(tc.v, tc.n)
+			if b.Len() != tc.n || b.String() != tc.expected {
+				t.Fatalf("[TestBuilderWriteBitsWide][%s]: Got %v (%d bits), expected %v (%d bits)", tc.name, b.String(), b.Len(), tc.expected, tc.n)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package bitop
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+var (
+	// ErrIndexOutOfRange is returned when an index lies outside the bits of the binary
+	ErrIndexOutOfRange = errors.New("bitop: index out of range")
+	// ErrLengthOverflow is returned when a length is negative, a value does not fit in its length,
+	// or a result would not fit in a uint
+	ErrLengthOverflow = errors.New("bitop: length overflow")
+	// ErrLengthMismatch is returned when a bit pattern is longer than the binary searched, or two binaries must be of equal length
+	ErrLengthMismatch = errors.New("bitop: length mismatch")
+	// ErrEmptyPattern is returned when a bit pattern to search for has zero length
+	ErrEmptyPattern = errors.New("bitop: empty pattern")
+)
+
+// Validate returns ErrLengthOverflow if the length of the unit is negative or larger than a uint,
+// or the value has bits set beyond the length
+func Validate(b Unit) error {
+	if b.leng < 0 || b.leng > bits.UintSize {
+		return fmt.Errorf("%w: length %d", ErrLengthOverflow, b.leng)
+	}
+	if bits.Len(b.value) > b.leng {
+		return fmt.Errorf("%w: value %b wider than length %d", ErrLengthOverflow, b.value, b.leng)
+	}
+	return nil
+}
+
+// ContainsErr is Contains returning an error on an invalid unit, an empty `sub` or a `sub` longer than `b`
+func ContainsErr(b, sub Unit) (bool, error) {
+	ind, err := IndexErr(b, sub)
+	return ind >= 0, err
+}
+
+// IndexErr is Index returning an error on an invalid unit, an empty `sub` or a `sub` longer than `b`
+func IndexErr(b, sub Unit) (int, error) {
+	if err := checkPattern(b, sub); err != nil {
+		return -1, err
+	}
+	return Index(b, sub), nil
+}
+
+// LastIndexErr is LastIndex returning an error on an invalid unit, an empty `sub` or a `sub` longer than `b`
+func LastIndexErr(b, sub Unit) (int, error) {
+	if err := checkPattern(b, sub); err != nil {
+		return -1, err
+	}
+	return LastIndex(b, sub), nil
+}
+
+// CountErr is Count returning an error on an invalid unit, an empty `sub` or a `sub` longer than `b`
+func CountErr(b, sub Unit) (int, error) {
+	if err := checkPattern(b, sub); err != nil {
+		return 0, err
+	}
+	return Count(b, sub), nil
+}
+
+// GetBitAtIndexErr is GetBitAtIndex returning an error if `ind` is not in [0, leng)
+func GetBitAtIndexErr(b Unit, ind int) (uint, error) {
+	if err := checkIndex(b, ind, b.leng-1); err != nil {
+		return 0, err
+	}
+	return GetBitAtIndex(b, ind), nil
+}
+
+// SplitAtErr is SplitAt returning an error if `ind` is not in [0, leng]
+func SplitAtErr(b Unit, ind int) ([]uint, error) {
+	if err := checkIndex(b, ind, b.leng); err != nil {
+		return nil, err
+	}
+	return SplitAt(b, ind), nil
+}
+
+// TruncateFromRightErr is TruncateFromRight returning an error if `pos` is not in [0, size of uint]
+func TruncateFromRightErr(b uint, pos int) (uint, error) {
+	if pos < 0 || pos > bits.UintSize {
+		return 0, fmt.Errorf("%w: position %d", ErrIndexOutOfRange, pos)
+	}
+	return TruncateFromRight(b, pos), nil
+}
+
+// ClearFromRightErr is ClearFromRight returning an error if `ind` is not in [0, leng]
+func ClearFromRightErr(b Unit, ind int) (uint, error) {
+	if err := checkIndex(b, ind, b.leng); err != nil {
+		return 0, err
+	}
+	return ClearFromRight(b, ind), nil
+}
+
+// TruncateFromLeftErr is TruncateFromLeft returning an error if `ind` is not in [0, leng]
+func TruncateFromLeftErr(b Unit, ind int) (uint, error) {
+	if err := checkIndex(b, ind, b.leng); err != nil {
+		return 0, err
+	}
+	return TruncateFromLeft(b, ind), nil
+}
+
+// RemoveBitErr is RemoveBit returning an error if `ind` is not in [0, leng)
+func RemoveBitErr(b Unit, ind int) (uint, error) {
+	if err := checkIndex(b, ind, b.leng-1); err != nil {
+		return 0, err
+	}
+	return RemoveBit(b, ind), nil
+}
+
+// FlipAtIndexErr is FlipAtIndex returning an error if `ind` is not in [0, leng)
+func FlipAtIndexErr(b Unit, ind int) (uint, error) {
+	if err := checkIndex(b, ind, b.leng-1); err != nil {
+		return 0, err
+	}
+	return FlipAtIndex(b, ind), nil
+}
+
+// JoinErr is Join returning an error on an invalid unit or if the joined binary does not fit in a uint
+func JoinErr(bs []Unit, sep Unit) (uint, error) {
+	if err := Validate(sep); err != nil {
+		return 0, err
+	}
+	leng := 0
+	for i, b := range bs {
+		if err := Validate(b); err != nil {
+			return 0, err
+		}
+		leng += b.leng
+		if i > 0 {
+			leng += sep.leng
+		}
+		if leng > bits.UintSize {
+			return 0, fmt.Errorf("%w: joined length exceeds %d", ErrLengthOverflow, bits.UintSize)
+		}
+	}
+	return Join(bs, sep), nil
+}
+
+// ColumnJoinErr is ColumnJoin returning an error if a row is wider than `colLeng`, or the columns do not fit in a uint
+func ColumnJoinErr(rows []uint, colLeng int) ([]uint, error) {
+	if colLeng < 0 || colLeng > bits.UintSize || len(rows) > bits.UintSize {
+		return nil, fmt.Errorf("%w: %d rows of %d bits", ErrLengthOverflow, len(rows), colLeng)
+	}
+	for _, row := range rows {
+		if bits.Len(row) > colLeng {
+			return nil, fmt.Errorf("%w: row %b wider than %d bits", ErrLengthOverflow, row, colLeng)
+		}
+	}
+	return ColumnJoin(rows, colLeng), nil
+}
+
+// RepeatErr is Repeat returning an error if `count` is negative or the repeated binary does not fit in a uint
+func RepeatErr(b Unit, count int) (uint, error) {
+	if err := Validate(b); err != nil {
+		return 0, err
+	}
+	if count < 0 || b.leng > 0 && count > bits.UintSize/b.leng {
+		return 0, fmt.Errorf("%w: %d repetitions of %d bits", ErrLengthOverflow, count, b.leng)
+	}
+	return Repeat(b, count), nil
+}
+
+// ReplaceErr is Replace returning an error on an invalid unit, an empty `old`, or if the result does not fit in a uint
+func ReplaceErr(b Unit, old Unit, new Unit, n int) (uint, error) {
+	if err := checkPattern(b, old); err != nil && !errors.Is(err, ErrLengthMismatch) {
+		return 0, err
+	}
+	if err := Validate(new); err != nil {
+		return 0, err
+	}
+	matches := len(IndexAll(b, old, false))
+	if n >= 0 && matches > n {
+		matches = n
+	}
+	if n >= 0 && b.leng+matches*(new.leng-old.leng) > bits.UintSize {
+		return 0, fmt.Errorf("%w: replaced length exceeds %d", ErrLengthOverflow, bits.UintSize)
+	}
+	return Replace(b, old, new, n), nil
+}
+
+// checkIndex validates the unit and returns ErrIndexOutOfRange if `ind` is not in [0, max]
+func checkIndex(b Unit, ind, max int) error {
+	if err := Validate(b); err != nil {
+		return err
+	}
+	if ind < 0 || ind > max {
+		return fmt.Errorf("%w: index %d, length %d", ErrIndexOutOfRange, ind, b.leng)
+	}
+	return nil
+}
+
+// checkPattern validates both units and returns an error if `sub` is empty or longer than `b`
+func checkPattern(b, sub Unit) error {
+	if err := Validate(b); err != nil {
+		return err
+	}
+	if err := Validate(sub); err != nil {
+		return err
+	}
+	if sub.leng == 0 {
+		return ErrEmptyPattern
+	}
+	if sub.leng > b.leng {
+		return fmt.Errorf("%w: pattern of %d bits in %d bits", ErrLengthMismatch, sub.leng, b.leng)
+	}
+	return nil
+}
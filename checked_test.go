@@ -0,0 +1,156 @@
+package bitop
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		expected error
+	}{
+		{
+			name:     "valid",
+			b:        NewUnit(0b0101, 6),
+			expected: nil,
+		},
+		{
+			name:     "empty",
+			b:        NewUnit(0, 0),
+			expected: nil,
+		},
+		{
+			name:     "negative length",
+			b:        Unit{value: 0, leng: -2},
+			expected: ErrLengthOverflow,
+		},
+		{
+			name:     "too long",
+			b:        NewUnit(0, 65),
+			expected: ErrLengthOverflow,
+		},
+		{
+			name:     "value wider than length",
+			b:        NewUnit(0b1111, 2),
+			expected: ErrLengthOverflow,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := Validate(tc.b)
+			if !errors.Is(result, tc.expected) {
+				t.Fatalf("[TestValidate][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestIndexErr(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		sub      Unit
+		expected int
+		err      error
+	}{
+		{
+			name:     "match",
+			b:        NewUnit(0b0110, 4),
+			sub:      NewUnit(0b11, 2),
+			expected: 1,
+		},
+		{
+			name:     "no match",
+			b:        NewUnit(0b0110, 4),
+			sub:      NewUnit(0b00, 2),
+			expected: -1,
+		},
+		{
+			name:     "empty pattern",
+			b:        NewUnit(0b0110, 4),
+			sub:      NewUnit(0, 0),
+			expected: -1,
+			err:      ErrEmptyPattern,
+		},
+		{
+			name:     "longer pattern",
+			b:        NewUnit(0b11, 2),
+			sub:      NewUnit(0b011, 3),
+			expected: -1,
+			err:      ErrLengthMismatch,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := IndexErr(tc.b, tc.sub)
+			if result != tc.expected || !errors.Is(err, tc.err) {
+				t.Fatalf("[TestIndexErr][%s]: Got %v %v, expected %v %v", tc.name, result, err, tc.expected, tc.err)
+			}
+			if found, err := ContainsErr(tc.b, tc.sub); found != (tc.expected >= 0) || !errors.Is(err, tc.err) {
+				t.Fatalf("[TestIndexErr][%s]: Got %v %v from ContainsErr", tc.name, found, err)
+			}
+		})
+	}
+}
+
+func TestIndexCheckedErrors(t *testing.T) {
+	t.Parallel()
+	b := NewUnit(0b0110, 4)
+	for _, tc := range []struct {
+		name string
+		err  func() error
+	}{
+		{"GetBitAtIndexErr negative", func() error { _, err := GetBitAtIndexErr(b, -1); return err }},
+		{"GetBitAtIndexErr past end", func() error { _, err := GetBitAtIndexErr(b, 4); return err }},
+		{"SplitAtErr past end", func() error { _, err := SplitAtErr(b, 5); return err }},
+		{"TruncateFromLeftErr past end", func() error { _, err := TruncateFromLeftErr(b, 5); return err }},
+		{"TruncateFromRightErr negative", func() error { _, err := TruncateFromRightErr(0b0110, -1); return err }},
+		{"ClearFromRightErr past end", func() error { _, err := ClearFromRightErr(b, 5); return err }},
+		{"RemoveBitErr past end", func() error { _, err := RemoveBitErr(b, 4); return err }},
+		{"FlipAtIndexErr negative", func() error { _, err := FlipAtIndexErr(b, -1); return err }},
+	} {
+		if err := tc.err(); !errors.Is(err, ErrIndexOutOfRange) {
+			t.Fatalf("[TestIndexCheckedErrors][%s]: Got %v, expected %v", tc.name, err, ErrIndexOutOfRange)
+		}
+	}
+
+	if result, err := SplitAtErr(b, 4); err != nil || result[0] != 0b0110 || result[1] != 0 {
+		t.Fatalf("[TestIndexCheckedErrors][SplitAtErr at end]: Got %v %v, expected [110 0]", result, err)
+	}
+	if result, err := GetBitAtIndexErr(b, 3); err != nil || result != 0 {
+		t.Fatalf("[TestIndexCheckedErrors][GetBitAtIndexErr]: Got %v %v, expected 0", result, err)
+	}
+}
+
+func TestLengthCheckedErrors(t *testing.T) {
+	t.Parallel()
+	word := NewUnit(0, 40)
+	for _, tc := range []struct {
+		name string
+		err  func() error
+	}{
+		{"JoinErr", func() error { _, err := JoinErr([]Unit{word, word}, NewUnit(0, 0)); return err }},
+		{"JoinErr invalid unit", func() error { _, err := JoinErr([]Unit{NewUnit(0b111, 1)}, NewUnit(0, 0)); return err }},
+		{"RepeatErr", func() error { _, err := RepeatErr(NewUnit(0b101, 3), 22); return err }},
+		{"RepeatErr negative", func() error { _, err := RepeatErr(NewUnit(0b101, 3), -1); return err }},
+		{"ColumnJoinErr wide row", func() error { _, err := ColumnJoinErr([]uint{0b1111}, 3); return err }},
+		{"ReplaceErr", func() error { _, err := ReplaceErr(NewUnit(0, 40), NewUnit(0, 1), NewUnit(0, 2), 30); return err }},
+	} {
+		if err := tc.err(); !errors.Is(err, ErrLengthOverflow) {
+			t.Fatalf("[TestLengthCheckedErrors][%s]: Got %v, expected %v", tc.name, err, ErrLengthOverflow)
+		}
+	}
+
+	if result, err := RepeatErr(NewUnit(0b101, 3), 21); err != nil || result != Repeat(NewUnit(0b101, 3), 21) {
+		t.Fatalf("[TestLengthCheckedErrors][RepeatErr]: Got %v %v, expected %v", result, err, Repeat(NewUnit(0b101, 3), 21))
+	}
+	if _, err := ReplaceErr(NewUnit(0b01, 2), NewUnit(0, 0), NewUnit(0, 1), 1); !errors.Is(err, ErrEmptyPattern) {
+		t.Fatalf("[TestLengthCheckedErrors][ReplaceErr empty]: Got %v, expected %v", err, ErrEmptyPattern)
+	}
+}
@@ -0,0 +1,41 @@
+package bitop
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// OnesCount returns the number of one bits within the length of the binary
+func OnesCount(b Unit) int {
+	return bits.OnesCount(b.value & mask(b.leng))
+}
+
+// HammingDistance returns the number of positions at which the bits of the binaries differ
+// Both binaries must be of the same length, otherwise ErrLengthMismatch is returned
+func HammingDistance(a, b Unit) (int, error) {
+	if a.leng != b.leng {
+		return 0, fmt.Errorf("%w: %d and %d bits", ErrLengthMismatch, a.leng, b.leng)
+	}
+	return OnesCount(Unit{value: a.value ^ b.value, leng: a.leng}), nil
+}
+
+// EditDistance returns the least number of bit insertions, deletions and flips that turn `a` into `b`
+func EditDistance(a, b Unit) int {
+	prev := make([]int, b.leng+1)
+	curr := make([]int, b.leng+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= a.leng; i++ {
+		curr[0] = i
+		for j := 1; j <= b.leng; j++ {
+			flip := prev[j-1]
+			if GetBitAtIndex(a, i-1) != GetBitAtIndex(b, j-1) {
+				flip++
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, flip)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[b.leng]
+}
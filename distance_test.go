@@ -0,0 +1,83 @@
+package bitop
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOnesCount(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		expected int
+	}{
+		{"empty", NewUnit(0, 0), 0},
+		{"leading zeroes", NewUnit(0b0101, 8), 2},
+		{"bits beyond length", NewUnit(0b1111, 2), 2},
+		{"full word", NewUnit(^uint(0), 64), 64},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := OnesCount(tc.b)
+			if result != tc.expected {
+				t.Fatalf("[TestOnesCount][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestHammingDistance(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		a        Unit
+		b        Unit
+		expected int
+		err      error
+	}{
+		{"equal", NewUnit(0b1011, 4), NewUnit(0b1011, 4), 0, nil},
+		{"differ", NewUnit(0b1011, 4), NewUnit(0b0110, 4), 3, nil},
+		{"leading zeroes", NewUnit(0b1, 8), NewUnit(0b10000000, 8), 2, nil},
+		{"width mismatch", NewUnit(0b1, 1), NewUnit(0b01, 2), 0, ErrLengthMismatch},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := HammingDistance(tc.a, tc.b)
+			if result != tc.expected || !errors.Is(err, tc.err) {
+				t.Fatalf("[TestHammingDistance][%s]: Got %v %v, expected %v %v", tc.name, result, err, tc.expected, tc.err)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		a        Unit
+		b        Unit
+		expected int
+	}{
+		{"equal", NewUnit(0b1011, 4), NewUnit(0b1011, 4), 0},
+		{"empty", NewUnit(0, 0), NewUnit(0b101, 3), 3},
+		{"flip", NewUnit(0b1011, 4), NewUnit(0b1001, 4), 1},
+		{"deletion", NewUnit(0b10110, 5), NewUnit(0b1010, 4), 1},
+		{"insertion", NewUnit(0b0101, 4), NewUnit(0b10101, 5), 1},
+		{"shifted", NewUnit(0b01010101, 8), NewUnit(0b10101010, 8), 2},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := EditDistance(tc.a, tc.b)
+			if result != tc.expected {
+				t.Fatalf("[TestEditDistance][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			if back := EditDistance(tc.b, tc.a); back != result {
+				t.Fatalf("[TestEditDistance][%s]: Got %v reversed, expected %v", tc.name, back, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package bitop
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// ErrInvalidEncoding is returned by UnmarshalBinary when the data was not produced by MarshalBinary
+var ErrInvalidEncoding = errors.New("bitop: invalid binary encoding")
+
+// MarshalText implements encoding.TextMarshaler, encoding the unit as its binary digits with leading zeros
+// An invalid unit returns the error of Validate
+func (b Unit) MarshalText() ([]byte, error) {
+	if err := Validate(b); err != nil {
+		return nil, err
+	}
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting any literal understood by Parse
+func (b *Unit) UnmarshalText(text []byte) error {
+	u, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*b = u
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the unit as a string of its binary digits
+// An invalid unit returns the error of Validate
+func (b Unit) MarshalJSON() ([]byte, error) {
+	if err := Validate(b); err != nil {
+		return nil, err
+	}
+	return json.Marshal(b.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a string holding any literal understood by Parse
+func (b *Unit) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return b.UnmarshalText([]byte(s))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+// The first byte holds the length, followed by the fewest big-endian bytes that hold the value
+// An invalid unit returns the error of Validate
+func (b Unit) MarshalBinary() ([]byte, error) {
+	if err := Validate(b); err != nil {
+		return nil, err
+	}
+	n := (b.leng + 7) / 8
+	data := make([]byte, 1+n)
+	data[0] = byte(b.leng)
+	for i := 0; i < n; i++ {
+		data[n-i] = byte(b.value >> uint(i*8))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, decoding the form written by MarshalBinary
+func (b *Unit) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("%w: missing length byte", ErrInvalidEncoding)
+	}
+	leng := int(data[0])
+	n := (leng + 7) / 8
+	if leng > bits.UintSize {
+		return fmt.Errorf("%w: length %d exceeds %d bits", ErrInvalidEncoding, leng, bits.UintSize)
+	}
+	if len(data) != 1+n {
+		return fmt.Errorf("%w: %d value bytes for length %d, expected %d", ErrInvalidEncoding, len(data)-1, leng, n)
+	}
+	u := Unit{leng: leng}
+	for _, d := range data[1:] {
+		u.value = u.value<<8 | uint(d)
+	}
+	if bits.Len(u.value) > leng {
+		return fmt.Errorf("%w: value wider than length %d", ErrInvalidEncoding, leng)
+	}
+	*b = u
+	return nil
+}
@@ -0,0 +1,117 @@
+package bitop
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestEncodingRoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name string
+		b    Unit
+		text string
+		bin  []byte
+	}{
+		{
+			name: "empty",
+			b:    NewUnit(0, 0),
+			text: "",
+			bin:  []byte{0},
+		},
+		{
+			name: "zero",
+			b:    NewUnit(0, 3),
+			text: "000",
+			bin:  []byte{3, 0},
+		},
+		{
+			name: "leading zeroes",
+			b:    NewUnit(0b101101, 12),
+			text: "000000101101",
+			bin:  []byte{12, 0b0000, 0b00101101},
+		},
+		{
+			name: "full word",
+			b:    NewUnit(^uint(0), -1),
+			text: "1111111111111111111111111111111111111111111111111111111111111111",
+			bin:  []byte{64, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			text, _ := tc.b.MarshalText()
+			if string(text) != tc.text {
+				t.Fatalf("[TestEncodingRoundTrip][%s]: Got %q, expected %q", tc.name, text, tc.text)
+			}
+			var fromText Unit
+			if err := fromText.UnmarshalText(text); err != nil || fromText != tc.b {
+				t.Fatalf("[TestEncodingRoundTrip][%s]: Got %v %v, expected %v", tc.name, fromText, err, tc.b)
+			}
+
+			js, err := json.Marshal(struct{ B Unit }{tc.b})
+			if expected := `{"B":"` + tc.text + `"}`; err != nil || string(js) != expected {
+				t.Fatalf("[TestEncodingRoundTrip][%s]: Got %s %v, expected %s", tc.name, js, err, expected)
+			}
+			var fromJSON struct{ B Unit }
+			if err := json.Unmarshal(js, &fromJSON); err != nil || fromJSON.B != tc.b {
+				t.Fatalf("[TestEncodingRoundTrip][%s]: Got %v %v, expected %v", tc.name, fromJSON.B, err, tc.b)
+			}
+
+			bin, _ := tc.b.MarshalBinary()
+			if string(bin) != string(tc.bin) {
+				t.Fatalf("[TestEncodingRoundTrip][%s]: Got %08b, expected %08b", tc.name, bin, tc.bin)
+			}
+			var fromBin Unit
+			if err := fromBin.UnmarshalBinary(bin); err != nil || fromBin != tc.b {
+				t.Fatalf("[TestEncodingRoundTrip][%s]: Got %v %v, expected %v", tc.name, fromBin, err, tc.b)
+			}
+		})
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name string
+		b    Unit
+	}{
+		{"value wider than length", NewUnit(0b111, 2)},
+		{"negative length", Unit{leng: -1}},
+		{"length beyond uint", Unit{leng: 300}},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if text, err := tc.b.MarshalText(); !errors.Is(err, ErrLengthOverflow) {
+				t.Fatalf("[TestMarshalErrors][%s]: Got %q %v, expected %v", tc.name, text, err, ErrLengthOverflow)
+			}
+			if js, err := json.Marshal(tc.b); !errors.Is(err, ErrLengthOverflow) {
+				t.Fatalf("[TestMarshalErrors][%s]: Got %s %v, expected %v", tc.name, js, err, ErrLengthOverflow)
+			}
+			if bin, err := tc.b.MarshalBinary(); !errors.Is(err, ErrLengthOverflow) {
+				t.Fatalf("[TestMarshalErrors][%s]: Got %v %v, expected %v", tc.name, bin, err, ErrLengthOverflow)
+			}
+		})
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	t.Parallel()
+	var b Unit
+	for _, js := range []string{`"0b012"`, `5`, `"0x"`} {
+		if err := json.Unmarshal([]byte(js), &b); err == nil {
+			t.Fatalf("[TestUnmarshalErrors][json %s]: Got %v, expected error", js, b)
+		}
+	}
+	for _, bin := range [][]byte{{}, {65, 0, 0, 0, 0, 0, 0, 0, 0, 0}, {3, 0, 0}, {3, 0b1000}} {
+		if err := b.UnmarshalBinary(bin); !errors.Is(err, ErrInvalidEncoding) {
+			t.Fatalf("[TestUnmarshalErrors][binary %v]: Got %v, expected %v", bin, err, ErrInvalidEncoding)
+		}
+	}
+	if err := json.Unmarshal([]byte(`"0x0F"`), &b); err != nil || b != NewUnit(0x0F, 8) {
+		t.Fatalf("[TestUnmarshalErrors][json prefix]: Got %v %v, expected %v", b, err, NewUnit(0x0F, 8))
+	}
+}
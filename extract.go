@@ -0,0 +1,62 @@
+package bitop
+
+import "math/bits"
+
+// Extract gathers the bits of `b` under the set bits of `m` into a right aligned binary, keeping their order
+// The binary and the mask are aligned to the right, the result has one bit per set bit of the mask
+func Extract(b, m Unit) Unit {
+	mk := m.value & mask(m.leng)
+	return Unit{value: compress(b.value&mask(b.leng), mk), leng: bits.OnesCount(mk)}
+}
+
+// Deposit scatters the rightmost bits of `b` to the positions of the set bits of `m`, keeping their order
+// The result has the length of the mask, it is the inverse of Extract
+func Deposit(b, m Unit) Unit {
+	mk := m.value & mask(m.leng)
+	return Unit{value: expand(b.value&mask(b.leng), mk), leng: m.leng}
+}
+
+// compress moves the bits of x selected by m to the right, in log2(UintSize) steps
+// See Hacker's Delight, section 7-4
+func compress(x, m uint) uint {
+	x &= m
+	mk := ^m << 1
+	for s := 1; s < bits.UintSize; s <<= 1 {
+		mp := suffixParity(mk)
+		mv := mp & m
+		m = m ^ mv | mv>>s
+		t := x & mv
+		x = x ^ t | t>>s
+		mk &^= mp
+	}
+	return x
+}
+
+// expand is the inverse of compress, it replays the moves of compress backwards
+func expand(x, m uint) uint {
+	var moves [8]uint
+	m0 := m
+	mk := ^m << 1
+	n := 0
+	for s := 1; s < bits.UintSize; s <<= 1 {
+		mp := suffixParity(mk)
+		mv := mp & m
+		moves[n] = mv
+		n++
+		m = m ^ mv | mv>>s
+		mk &^= mp
+	}
+	for i := n - 1; i >= 0; i-- {
+		mv := moves[i]
+		x = x&^mv | x<<(1<<i)&mv
+	}
+	return x & m0
+}
+
+// suffixParity sets each bit to the parity of the bits at and to the right of it
+func suffixParity(x uint) uint {
+	for s := 1; s < bits.UintSize; s <<= 1 {
+		x ^= x << s
+	}
+	return x
+}
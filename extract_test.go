@@ -0,0 +1,149 @@
+package bitop
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		m        Unit
+		expected Unit
+	}{
+		{"empty mask", NewUnit(0b1011, 4), NewUnit(0, 4), NewUnit(0, 0)},
+		{"full mask", NewUnit(0b1011, 4), NewUnit(0b1111, 4), NewUnit(0b1011, 4)},
+		{"scattered", NewUnit(0b10110110, 8), NewUnit(0b11001010, 8), NewUnit(0b1001, 4)},
+		{"leading zeroes", NewUnit(0b0011, 4), NewUnit(0b1101, 4), NewUnit(0b001, 3)},
+		{"shorter binary", NewUnit(0b11, 2), NewUnit(0b1111, 4), NewUnit(0b0011, 4)},
+		{"bits beyond mask length", NewUnit(0b1111, 4), NewUnit(0b1100, 2), NewUnit(0, 0)},
+		{"full word", NewUnit(^uint(0)>>1, 64), NewUnit(1<<63|1, 64), NewUnit(0b01, 2)},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := Extract(tc.b, tc.m)
+			if result != tc.expected {
+				t.Fatalf("[TestExtract][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestDeposit(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		m        Unit
+		expected Unit
+	}{
+		{"empty mask", NewUnit(0b1011, 4), NewUnit(0, 4), NewUnit(0, 4)},
+		{"full mask", NewUnit(0b1011, 4), NewUnit(0b1111, 4), NewUnit(0b1011, 4)},
+		{"scattered", NewUnit(0b1011, 4), NewUnit(0b11001010, 8), NewUnit(0b10001010, 8)},
+		{"excess bits dropped", NewUnit(0b110, 3), NewUnit(0b0101, 4), NewUnit(0b0100, 4)},
+		{"full word", NewUnit(0b01, 2), NewUnit(1<<63|1, 64), NewUnit(1, 64)},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := Deposit(tc.b, tc.m)
+			if result != tc.expected {
+				t.Fatalf("[TestDeposit][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestExtractDepositRandom(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		leng := r.Intn(bits.UintSize + 1)
+		b := NewUnit(uint(r.Uint64())&mask(leng), leng)
+		m := NewUnit(uint(r.Uint64()&r.Uint64())&mask(leng), leng)
+		e := Extract(b, m)
+		if expected := extractNaive(b, m); e != expected {
+			t.Fatalf("[TestExtractDepositRandom][%v, %v]: Got %v, expected %v", b, m, e, expected)
+		}
+		if d, expected := Deposit(e, m), NewUnit(b.Value()&m.Value(), leng); d != expected {
+			t.Fatalf("[TestExtractDepositRandom][%v, %v]: Got %v, expected %v", e, m, d, expected)
+		}
+		if d, expected := Deposit(b, m), depositNaive(b, m); d != expected {
+			t.Fatalf("[TestExtractDepositRandom][%v, %v]: Got %v, expected %v", b, m, d, expected)
+		}
+	}
+}
+
+// extractNaive gathers the masked bits one by one from left to right
+func extractNaive(b, m Unit) Unit {
+	var v uint
+	n := 0
+	for i := 0; i < m.Len(); i++ {
+		if GetBitAtIndex(m, i) == 1 {
+			v = v<<1 | GetBitAtIndex(b, i-m.Len()+b.Len())
+			n++
+		}
+	}
+	return NewUnit(v, n)
+}
+
+// depositNaive scatters the bits one by one, setting them with FlipAtIndex
+func depositNaive(b, m Unit) Unit {
+	var v uint
+	j := b.Len() - OnesCount(m)
+	for i := 0; i < m.Len(); i++ {
+		if GetBitAtIndex(m, i) == 1 {
+			if GetBitAtIndex(b, j) == 1 {
+				v = FlipAtIndex(NewUnit(v, m.Len()), i)
+			}
+			j++
+		}
+	}
+	return NewUnit(v, m.Len())
+}
+
+func benchmarkUnits(n int) ([]Unit, []Unit) {
+	r := rand.New(rand.NewSource(1))
+	bs, ms := make([]Unit, n), make([]Unit, n)
+	for i := range bs {
+		bs[i] = NewUnit(uint(r.Uint64()), bits.UintSize)
+		ms[i] = NewUnit(uint(r.Uint64()), bits.UintSize)
+	}
+	return bs, ms
+}
+
+func BenchmarkExtract(b *testing.B) {
+	bs, ms := benchmarkUnits(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Extract(bs[i&1023], ms[i&1023])
+	}
+}
+
+func BenchmarkExtractNaive(b *testing.B) {
+	bs, ms := benchmarkUnits(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		extractNaive(bs[i&1023], ms[i&1023])
+	}
+}
+
+func BenchmarkDeposit(b *testing.B) {
+	bs, ms := benchmarkUnits(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Deposit(bs[i&1023], ms[i&1023])
+	}
+}
+
+func BenchmarkDepositNaive(b *testing.B) {
+	bs, ms := benchmarkUnits(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		depositNaive(bs[i&1023], ms[i&1023])
+	}
+}
@@ -0,0 +1,140 @@
+package bitop
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// ErrSyntax is returned by Parse when the text is not a valid binary, octal or hexadecimal literal
+var ErrSyntax = errors.New("bitop: invalid literal")
+
+// Parse returns the unit written in the text, with the length given by the number of digits so leading zeros are kept
+// The text is binary by default, prefixes 0b, 0o and 0x select binary, octal (3 bits per digit) and hexadecimal (4 bits per digit)
+// Underscores may be used between digits or right after a prefix, as in Go number literals
+// The length is capped at the size of a uint, any digits beyond it must only carry leading zeros
+func Parse(s string) (Unit, error) {
+	digits, shift := s, 1
+	// A prefix counts as a digit, so an underscore may follow it
+	afterDigit := false
+	if len(s) >= 2 && s[0] == '0' {
+		switch s[1] {
+		case 'b', 'B':
+			digits, shift = s[2:], 1
+		case 'o', 'O':
+			digits, shift = s[2:], 3
+		case 'x', 'X':
+			digits, shift = s[2:], 4
+		}
+		if len(digits) < len(s) {
+			if strings.Trim(digits, "_") == "" {
+				return Unit{}, fmt.Errorf("%w %q", ErrSyntax, s)
+			}
+			afterDigit = true
+		}
+	}
+
+	b := Unit{}
+	for i, c := range digits {
+		if c == '_' {
+			if !afterDigit || i == len(digits)-1 {
+				return Unit{}, fmt.Errorf("%w: misplaced underscore in %q", ErrSyntax, s)
+			}
+			afterDigit = false
+			continue
+		}
+		afterDigit = true
+		d, ok := digitValue(c)
+		if !ok || d >= 1<<shift {
+			return Unit{}, fmt.Errorf("%w %q", ErrSyntax, s)
+		}
+		if b.leng+shift > bits.UintSize {
+			if b.value>>uint(bits.UintSize-shift) != 0 {
+				return Unit{}, fmt.Errorf("%w: %q is longer than %d bits", ErrLengthOverflow, s, bits.UintSize)
+			}
+			b.leng = bits.UintSize - shift
+		}
+		b.value = b.value<<shift | d
+		b.leng += shift
+	}
+	return b, nil
+}
+
+// digitValue returns the value of a hexadecimal digit
+func digitValue(c rune) (uint, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return uint(c - '0'), true
+	case 'a' <= c && c <= 'f':
+		return uint(c - 'a' + 10), true
+	case 'A' <= c && c <= 'F':
+		return uint(c - 'A' + 10), true
+	}
+	return 0, false
+}
+
+// String returns the binary digits of the unit, leading zeros included
+func (b Unit) String() string {
+	return b.digits(1, false, 0)
+}
+
+// Format implements fmt.Formatter, printing exactly as many digits as the length of the unit requires
+// Verbs %b, %o, %x and %X print binary, octal and hexadecimal digits, %v and %s print binary and %d the decimal value
+// The # flag adds the 0b, 0o or 0x prefix, and the precision groups the digits from the right separated by underscores,
+// e.g. %.4b prints nibbles and %.8b prints bytes
+func (b Unit) Format(f fmt.State, verb rune) {
+	group, _ := f.Precision()
+	var s, prefix string
+	switch verb {
+	case 'b', 'v', 's':
+		s, prefix = b.digits(1, false, group), "0b"
+	case 'o', 'O':
+		s, prefix = b.digits(3, false, group), "0o"
+	case 'x':
+		s, prefix = b.digits(4, false, group), "0x"
+	case 'X':
+		s, prefix = b.digits(4, true, group), "0X"
+	case 'd':
+		s = strconv.FormatUint(uint64(b.value), 10)
+	default:
+		fmt.Fprintf(f, "%%!%c(bitop.Unit=%s)", verb, b.String())
+		return
+	}
+	if f.Flag('#') || verb == 'O' {
+		s = prefix + s
+	}
+
+	width, _ := f.Width()
+	pad := ""
+	if width > len(s) {
+		pad = strings.Repeat(" ", width-len(s))
+	}
+	if f.Flag('-') {
+		s += pad
+	} else {
+		s = pad + s
+	}
+	f.Write([]byte(s))
+}
+
+// digits returns the digits of the unit where each digit holds `shift` bits, separated by underscores every `group` digits
+func (b Unit) digits(shift int, upper bool, group int) string {
+	const lower, capital = "0123456789abcdef", "0123456789ABCDEF"
+	chars := lower
+	if upper {
+		chars = capital
+	}
+
+	n := (b.leng + shift - 1) / shift
+	var sb strings.Builder
+	sb.Grow(n + n/4)
+	for i := n - 1; i >= 0; i-- {
+		sb.WriteByte(chars[b.value>>uint(i*shift)&(1<<shift-1)])
+		if group > 0 && i > 0 && i%group == 0 {
+			sb.WriteByte('_')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,204 @@
+package bitop
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		s        string
+		expected Unit
+		err      error
+	}{
+		{
+			name:     "empty",
+			s:        "",
+			expected: NewUnit(0, 0),
+		},
+		{
+			name:     "leading zeroes",
+			s:        "00101101",
+			expected: NewUnit(0b00101101, 8),
+		},
+		{
+			name:     "binary prefix with underscores",
+			s:        "0b0010_1101",
+			expected: NewUnit(0b00101101, 8),
+		},
+		{
+			name:     "octal",
+			s:        "0o017",
+			expected: NewUnit(0o17, 9),
+		},
+		{
+			name:     "hexadecimal",
+			s:        "0X0aF",
+			expected: NewUnit(0xAF, 12),
+		},
+		{
+			name: "invalid digit",
+			s:    "0b0120",
+			err:  ErrSyntax,
+		},
+		{
+			name: "prefix only",
+			s:    "0x",
+			err:  ErrSyntax,
+		},
+		{
+			name:     "underscore after prefix",
+			s:        "0x_0f",
+			expected: NewUnit(0x0F, 8),
+		},
+		{
+			name: "prefix and underscore only",
+			s:    "0x_",
+			err:  ErrSyntax,
+		},
+		{
+			name: "underscores only",
+			s:    "__",
+			err:  ErrSyntax,
+		},
+		{
+			name: "leading underscore",
+			s:    "_1",
+			err:  ErrSyntax,
+		},
+		{
+			name: "trailing underscore",
+			s:    "1_",
+			err:  ErrSyntax,
+		},
+		{
+			name: "double underscore",
+			s:    "0b1__0",
+			err:  ErrSyntax,
+		},
+		{
+			name: "too long",
+			s:    "0x1_0000_0000_0000_0000",
+			err:  ErrLengthOverflow,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := Parse(tc.s)
+			if !errors.Is(err, tc.err) || result != tc.expected {
+				t.Fatalf("[TestParse][%s]: Got %v %v, expected %v %v", tc.name, result, err, tc.expected, tc.err)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		format   string
+		b        Unit
+		expected string
+	}{
+		{
+			name:     "string",
+			format:   "%v",
+			b:        NewUnit(0b101, 6),
+			expected: "000101",
+		},
+		{
+			name:     "empty",
+			format:   "%s",
+			b:        NewUnit(0, 0),
+			expected: "",
+		},
+		{
+			name:     "prefix",
+			format:   "%#b",
+			b:        NewUnit(0b101, 4),
+			expected: "0b0101",
+		},
+		{
+			name:     "nibbles",
+			format:   "%.4b",
+			b:        NewUnit(0b0010110101, 10),
+			expected: "00_1011_0101",
+		},
+		{
+			name:     "bytes",
+			format:   "%#.8b",
+			b:        NewUnit(0x00FF, 16),
+			expected: "0b00000000_11111111",
+		},
+		{
+			name:     "hexadecimal",
+			format:   "%x",
+			b:        NewUnit(0xA, 12),
+			expected: "00a",
+		},
+		{
+			name:     "upper hexadecimal",
+			format:   "%#X",
+			b:        NewUnit(0xAB, 9),
+			expected: "0X0AB",
+		},
+		{
+			name:     "octal",
+			format:   "%o",
+			b:        NewUnit(0o7, 7),
+			expected: "007",
+		},
+		{
+			name:     "decimal",
+			format:   "%d",
+			b:        NewUnit(0b1010, 8),
+			expected: "10",
+		},
+		{
+			name:     "width",
+			format:   "[%6b][%-6b]",
+			b:        NewUnit(0b1, 3),
+			expected: "[   001][001   ]",
+		},
+		{
+			name:     "bad verb",
+			format:   "%q",
+			b:        NewUnit(0b1, 2),
+			expected: "%!q(bitop.Unit=01)",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var result string
+			if tc.name == "width" {
+				result = fmt.Sprintf(tc.format, tc.b, tc.b)
+			} else {
+				result = fmt.Sprintf(tc.format, tc.b)
+			}
+			if result != tc.expected {
+				t.Fatalf("[TestFormat][%s]: Got %q, expected %q", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseFormatRoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, format := range []string{"%v", "%#b", "%#.4b", "%#o", "%#x"} {
+		for leng := 4; leng <= 64; leng += 4 {
+			b := NewUnit(0x5A5A5A5A5A5A5A5A>>(64-leng), leng)
+			result, err := Parse(fmt.Sprintf(format, b))
+			if format == "%#o" {
+				result.leng = leng
+			}
+			if err != nil || result != b {
+				t.Fatalf("[TestParseFormatRoundTrip][%s]: Got %v %v, expected %v", format, result, err, b)
+			}
+		}
+	}
+}
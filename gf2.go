@@ -0,0 +1,162 @@
+package bitop
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+var (
+	// ErrInconsistent is returned when a system of linear equations over GF(2) has no solution
+	ErrInconsistent = errors.New("bitop: inconsistent linear system")
+	// ErrSingular is returned when a square matrix over GF(2) has no inverse
+	ErrSingular = errors.New("bitop: singular matrix")
+)
+
+// NewIdentityBitMatrix returns the n × n matrix with ones on the diagonal
+func NewIdentityBitMatrix(n int) *BitMatrix {
+	m := NewBitMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m.Set(i, i, 1)
+	}
+	return m
+}
+
+// Rank returns the number of linearly independent rows of the matrix over GF(2)
+func (m *BitMatrix) Rank() int {
+	return len(m.Clone().eliminate(nil))
+}
+
+// RowReduce returns the reduced row echelon form of the matrix over GF(2), along with the pivot column of each non-zero row
+func (m *BitMatrix) RowReduce() (*BitMatrix, []int) {
+	r := m.Clone()
+	pivots := r.eliminate(nil)
+	return r, pivots
+}
+
+// Solve returns x such that a·x = b over GF(2), free variables are set to zero
+// Returns ErrLengthMismatch if b does not have one bit per row of a, or ErrInconsistent if there is no solution
+func Solve(a *BitMatrix, b BitString) (BitString, error) {
+	if b.leng != a.rows {
+		return BitString{}, fmt.Errorf("%w: %d rows and %d bits", ErrLengthMismatch, a.rows, b.leng)
+	}
+	aug := BitMatrixFromRows([]BitString{b}).Transpose()
+	pivots := a.Clone().eliminate(aug)
+	for i := len(pivots); i < a.rows; i++ {
+		if aug.Get(i, 0) == 1 {
+			return BitString{}, fmt.Errorf("%w: row %d reduces to 0 = 1", ErrInconsistent, i)
+		}
+	}
+	x := NewBitString(a.cols)
+	for i, c := range pivots {
+		if aug.Get(i, 0) == 1 {
+			x.words[c/64] |= 1 << (63 - c%64)
+		}
+	}
+	return x, nil
+}
+
+// Nullspace returns a basis of the vectors x with m·x = 0 over GF(2), one vector per row
+func (m *BitMatrix) Nullspace() *BitMatrix {
+	r, pivots := m.RowReduce()
+	isPivot := make([]bool, m.cols)
+	for _, c := range pivots {
+		isPivot[c] = true
+	}
+	basis := NewBitMatrix(m.cols-len(pivots), m.cols)
+	k := 0
+	for f := 0; f < m.cols; f++ {
+		if isPivot[f] {
+			continue
+		}
+		basis.Set(k, f, 1)
+		for i, c := range pivots {
+			basis.Set(k, c, r.Get(i, f))
+		}
+		k++
+	}
+	return basis
+}
+
+// Inverse returns the inverse of a square matrix over GF(2)
+// Returns ErrLengthMismatch if the matrix is not square, or ErrSingular if it has no inverse
+func (m *BitMatrix) Inverse() (*BitMatrix, error) {
+	if m.rows != m.cols {
+		return nil, fmt.Errorf("%w: %d × %d matrix is not square", ErrLengthMismatch, m.rows, m.cols)
+	}
+	inv := NewIdentityBitMatrix(m.rows)
+	if rank := len(m.Clone().eliminate(inv)); rank < m.rows {
+		return nil, fmt.Errorf("%w: rank %d of %d", ErrSingular, rank, m.rows)
+	}
+	return inv, nil
+}
+
+// Mul returns the product m·n over GF(2), each bit is the parity of the AND of a row of m and a column of n
+// Returns ErrLengthMismatch if the columns of m do not match the rows of n
+func (m *BitMatrix) Mul(n *BitMatrix) (*BitMatrix, error) {
+	if m.cols != n.rows {
+		return nil, fmt.Errorf("%w: %d × %d and %d × %d matrices", ErrLengthMismatch, m.rows, m.cols, n.rows, n.cols)
+	}
+	nt := n.Transpose()
+	p := NewBitMatrix(m.rows, n.cols)
+	for i := 0; i < m.rows; i++ {
+		row := m.row(i)
+		for j := 0; j < n.cols; j++ {
+			ones := 0
+			for k, w := range nt.row(j) {
+				ones += bits.OnesCount64(row[k] & w)
+			}
+			p.Set(i, j, uint(ones&1))
+		}
+	}
+	return p, nil
+}
+
+// eliminate reduces m in place to reduced row echelon form by Gauss-Jordan elimination, and returns the pivot columns
+// The same row operations are applied to aug if it is not nil, it must have as many rows as m
+func (m *BitMatrix) eliminate(aug *BitMatrix) []int {
+	var pivots []int
+	for c := 0; c < m.cols && len(pivots) < m.rows; c++ {
+		r := len(pivots)
+		p := r
+		for p < m.rows && m.Get(p, c) == 0 {
+			p++
+		}
+		if p == m.rows {
+			continue
+		}
+		m.swapRows(r, p)
+		if aug != nil {
+			aug.swapRows(r, p)
+		}
+		for i := 0; i < m.rows; i++ {
+			if i != r && m.Get(i, c) == 1 {
+				m.xorRow(i, r)
+				if aug != nil {
+					aug.xorRow(i, r)
+				}
+			}
+		}
+		pivots = append(pivots, c)
+	}
+	return pivots
+}
+
+// swapRows exchanges rows i and j
+func (m *BitMatrix) swapRows(i, j int) {
+	if i == j {
+		return
+	}
+	ri, rj := m.row(i), m.row(j)
+	for k := range ri {
+		ri[k], rj[k] = rj[k], ri[k]
+	}
+}
+
+// xorRow adds row j to row i
+func (m *BitMatrix) xorRow(i, j int) {
+	ri, rj := m.row(i), m.row(j)
+	for k := range ri {
+		ri[k] ^= rj[k]
+	}
+}
@@ -0,0 +1,157 @@
+package bitop
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// randomFullRank returns a random n × n matrix with an inverse
+func randomFullRank(r *rand.Rand, n int) *BitMatrix {
+	for {
+		m := randomBitMatrix(r, n, n, 0.5)
+		if m.Rank() == n {
+			return m
+		}
+	}
+}
+
+// randomSingular returns a random n × n matrix, n at least 3, with one row the sum of two others
+func randomSingular(r *rand.Rand, n int) *BitMatrix {
+	m := randomBitMatrix(r, n, n, 0.5)
+	m.SetRow(n-1, m.Row(0))
+	m.xorRow(n-1, 1)
+	return m
+}
+
+// column returns the bit string as an n × 1 matrix
+func column(s BitString) *BitMatrix {
+	return BitMatrixFromRows([]BitString{s}).Transpose()
+}
+
+func TestRowReduce(t *testing.T) {
+	t.Parallel()
+	m := BitMatrixFromRows([]BitString{bs("0110"), bs("1100"), bs("1010")})
+	r, pivots := m.RowReduce()
+	if expected := "1010\n0110\n0000"; r.String() != expected {
+		t.Fatalf("[TestRowReduce]: Got %v, expected %v", r, expected)
+	}
+	if len(pivots) != 2 || pivots[0] != 0 || pivots[1] != 1 {
+		t.Fatalf("[TestRowReduce]: Got pivots %v, expected %v", pivots, []int{0, 1})
+	}
+	if m.String() != "0110\n1100\n1010" {
+		t.Fatalf("[TestRowReduce]: matrix changed to %v", m)
+	}
+}
+
+func TestRank(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		m        *BitMatrix
+		expected int
+	}{
+		{"empty", NewBitMatrix(0, 0), 0},
+		{"zeros", NewBitMatrix(3, 5), 0},
+		{"identity", NewIdentityBitMatrix(70), 70},
+		{"dependent rows", BitMatrixFromRows([]BitString{bs("0110"), bs("1100"), bs("1010")}), 2},
+		{"wide", BitMatrixFromRows([]BitString{bs("10000001"), bs("01000001")}), 2},
+		{"tall", BitMatrixFromRows([]BitString{bs("10"), bs("11"), bs("01")}), 2},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := tc.m.Rank()
+			if result != tc.expected {
+				t.Fatalf("[TestRank][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestInverse(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 5, 64, 100} {
+		m := randomFullRank(r, n)
+		inv, err := m.Inverse()
+		if err != nil {
+			t.Fatalf("[TestInverse][%d]: Got %v, expected %v", n, err, nil)
+		}
+		p, _ := m.Mul(inv)
+		if !p.Equal(NewIdentityBitMatrix(n)) {
+			t.Fatalf("[TestInverse][%d]: Got %v, expected the identity", n, p)
+		}
+		if n < 3 {
+			continue
+		}
+		if _, err := randomSingular(r, n).Inverse(); !errors.Is(err, ErrSingular) {
+			t.Fatalf("[TestInverse][%d singular]: Got %v, expected %v", n, err, ErrSingular)
+		}
+	}
+	if _, err := NewBitMatrix(2, 3).Inverse(); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("[TestInverse][not square]: Got %v, expected %v", err, ErrLengthMismatch)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(2))
+	for _, n := range []int{3, 10, 80} {
+		for _, a := range []*BitMatrix{randomFullRank(r, n), randomSingular(r, n), randomBitMatrix(r, n, n+20, 0.5)} {
+			want := randomBitString(r, a.Cols(), 0.5)
+			b, _ := a.Mul(column(want))
+			x, err := Solve(a, b.Col(0))
+			if err != nil {
+				t.Fatalf("[TestSolve][%d × %d]: Got %v, expected %v", a.Rows(), a.Cols(), err, nil)
+			}
+			if ax, _ := a.Mul(column(x)); !ax.Equal(b) {
+				t.Fatalf("[TestSolve][%d × %d]: Got a·x = %v, expected %v", a.Rows(), a.Cols(), ax.Col(0), b.Col(0))
+			}
+		}
+	}
+
+	a := BitMatrixFromRows([]BitString{bs("110"), bs("011"), bs("101")})
+	if _, err := Solve(a, bs("001")); !errors.Is(err, ErrInconsistent) {
+		t.Fatalf("[TestSolve][inconsistent]: Got %v, expected %v", err, ErrInconsistent)
+	}
+	if _, err := Solve(a, bs("00")); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("[TestSolve][length]: Got %v, expected %v", err, ErrLengthMismatch)
+	}
+}
+
+func TestNullspace(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(3))
+	for _, m := range []*BitMatrix{
+		randomFullRank(r, 20),
+		randomSingular(r, 20),
+		randomBitMatrix(r, 10, 75, 0.5),
+		randomBitMatrix(r, 75, 10, 0.1),
+		NewBitMatrix(4, 6),
+	} {
+		basis := m.Nullspace()
+		if basis.Rows() != m.Cols()-m.Rank() {
+			t.Fatalf("[TestNullspace][%d × %d]: Got %v vectors, expected %v", m.Rows(), m.Cols(), basis.Rows(), m.Cols()-m.Rank())
+		}
+		if basis.Rank() != basis.Rows() {
+			t.Fatalf("[TestNullspace][%d × %d]: basis vectors are not independent", m.Rows(), m.Cols())
+		}
+		if p, _ := m.Mul(basis.Transpose()); p.Rank() != 0 {
+			t.Fatalf("[TestNullspace][%d × %d]: Got m·x = %v, expected zero", m.Rows(), m.Cols(), p)
+		}
+	}
+}
+
+func TestMul(t *testing.T) {
+	t.Parallel()
+	a := BitMatrixFromRows([]BitString{bs("110"), bs("011")})
+	b := BitMatrixFromRows([]BitString{bs("10"), bs("11"), bs("01")})
+	p, err := a.Mul(b)
+	if expected := "01\n10"; err != nil || p.String() != expected {
+		t.Fatalf("[TestMul]: Got %v (%v), expected %v", p, err, expected)
+	}
+	if _, err := a.Mul(a); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("[TestMul][mismatch]: Got %v, expected %v", err, ErrLengthMismatch)
+	}
+}
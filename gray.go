@@ -0,0 +1,41 @@
+package bitop
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// ToGray returns the reflected binary Gray code of the binary, keeping its length
+func ToGray(b Unit) Unit {
+	return Unit{value: b.value ^ b.value>>1, leng: b.leng}
+}
+
+// FromGray returns the binary whose reflected binary Gray code is `g`, keeping its length
+func FromGray(g Unit) Unit {
+	value := g.value
+	for shift := uint(1); shift < bits.UintSize; shift <<= 1 {
+		value ^= value >> shift
+	}
+	return Unit{value: value, leng: g.leng}
+}
+
+// GrayCodes returns an iterator over all n-bit Gray codes in order, starting from zero
+// Along with each code it yields the index of the bit flipped from the previous code, -1 for the first code
+func GrayCodes(n int) iter.Seq2[Unit, int] {
+	return func(yield func(Unit, int) bool) {
+		if n < 0 || n > bits.UintSize {
+			return
+		}
+		code := Unit{leng: n}
+		if !yield(code, -1) {
+			return
+		}
+		for k := uint(1); k != 0 && k <= mask(n); k++ {
+			ind := n - 1 - bits.TrailingZeros(k)
+			code = Unit{value: FlipAtIndex(code, ind), leng: n}
+			if !yield(code, ind) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package bitop
+
+import (
+	"testing"
+)
+
+func TestToGray(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		expected Unit
+	}{
+		{"zero", NewUnit(0, 4), NewUnit(0, 4)},
+		{"one", NewUnit(0b0001, 4), NewUnit(0b0001, 4)},
+		{"seven", NewUnit(0b0111, 4), NewUnit(0b0100, 4)},
+		{"eight", NewUnit(0b1000, 4), NewUnit(0b1100, 4)},
+		{"full word", NewUnit(^uint(0), 64), NewUnit(1<<63, 64)},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := ToGray(tc.b)
+			if result != tc.expected {
+				t.Fatalf("[TestToGray][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			if back := FromGray(result); back != tc.b {
+				t.Fatalf("[TestToGray][%s]: Got %v, expected %v after FromGray", tc.name, back, tc.b)
+			}
+		})
+	}
+}
+
+func TestGrayCodes(t *testing.T) {
+	t.Parallel()
+	for _, n := range []int{0, 1, 3, 8} {
+		seen := map[Unit]bool{}
+		step := 0
+		var prev Unit
+		for code, ind := range GrayCodes(n) {
+			if code != ToGray(NewUnit(uint(step), n)) {
+				t.Fatalf("[TestGrayCodes][%d]: Got %v at step %d, expected %v", n, code, step, ToGray(NewUnit(uint(step), n)))
+			}
+			if step == 0 && ind != -1 || step > 0 && FlipAtIndex(prev, ind) != code.value {
+				t.Fatalf("[TestGrayCodes][%d]: Got changed index %d from %v to %v", n, ind, prev, code)
+			}
+			seen[code] = true
+			prev = code
+			step++
+		}
+		if step != 1<<n || len(seen) != 1<<n {
+			t.Fatalf("[TestGrayCodes][%d]: Got %d codes, expected %d", n, step, 1<<n)
+		}
+	}
+
+	count := 0
+	for range GrayCodes(64) {
+		if count++; count == 100 {
+			break
+		}
+	}
+	if count != 100 {
+		t.Fatalf("[TestGrayCodes][64]: Got %d codes before break, expected %d", count, 100)
+	}
+}
@@ -0,0 +1,130 @@
+package bitop
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// Pattern is a bit pattern where some bits are "don't care", only the bits set in care are compared
+type Pattern struct {
+	value uint
+	care  uint
+	leng  int
+}
+
+// NewPattern returns a pattern of length leng matching `value` on the bits set in `care`
+func NewPattern(value, care uint, leng int) Pattern {
+	care &= mask(leng)
+	return Pattern{value: value & care, care: care, leng: leng}
+}
+
+// ParsePattern returns the pattern written as 0s, 1s and don't care bits x, X or ?, e.g. "10x1xx0"
+// Underscores may be used between bits
+func ParsePattern(s string) (Pattern, error) {
+	p := Pattern{}
+	afterBit := false
+	for i, c := range s {
+		if c == '_' {
+			if !afterBit || i == len(s)-1 {
+				return Pattern{}, fmt.Errorf("%w: misplaced underscore in %q", ErrSyntax, s)
+			}
+			afterBit = false
+			continue
+		}
+		afterBit = true
+		if p.leng == bits.UintSize {
+			return Pattern{}, fmt.Errorf("%w: %q is longer than %d bits", ErrLengthOverflow, s, bits.UintSize)
+		}
+		p.value <<= 1
+		p.care <<= 1
+		p.leng++
+		switch c {
+		case '0':
+			p.care |= 1
+		case '1':
+			p.value |= 1
+			p.care |= 1
+		case 'x', 'X', '?':
+		default:
+			return Pattern{}, fmt.Errorf("%w %q", ErrSyntax, s)
+		}
+	}
+	return p, nil
+}
+
+// Len returns the number of bits in the pattern
+func (p Pattern) Len() int {
+	return p.leng
+}
+
+// String returns the pattern as 0s, 1s and x for don't care bits
+func (p Pattern) String() string {
+	var sb strings.Builder
+	for i := p.leng - 1; i >= 0; i-- {
+		switch {
+		case p.care>>uint(i)&1 == 0:
+			sb.WriteByte('x')
+		case p.value>>uint(i)&1 == 1:
+			sb.WriteByte('1')
+		default:
+			sb.WriteByte('0')
+		}
+	}
+	return sb.String()
+}
+
+// MatchAt returns true if the bits of `b` starting at index `ind` match the pattern
+func MatchAt(b Unit, p Pattern, ind int) bool {
+	if ind < 0 || ind > b.leng-p.leng {
+		return false
+	}
+	window := TruncateFromLeft(b, ind)
+	window = TruncateFromRight(window, b.leng-ind-p.leng)
+	return window&p.care == p.value
+}
+
+// ContainsPattern returns true if the binary `b` has at least one section that matches the pattern
+func ContainsPattern(b Unit, p Pattern) bool {
+	return IndexPattern(b, p) >= 0
+}
+
+// IndexPattern returns the first index where the pattern matches, if no matching found -1 is returned
+func IndexPattern(b Unit, p Pattern) int {
+	for i := 0; i <= b.leng-p.leng; i++ {
+		if MatchAt(b, p, i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// LastIndexPattern returns the last index where the pattern matches, if no matching found -1 is returned
+func LastIndexPattern(b Unit, p Pattern) int {
+	for i := b.leng - p.leng; i >= 0; i-- {
+		if MatchAt(b, p, i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ReplacePattern returns a binary with any section matching the pattern replaced by new, up to n times of occurrences
+func ReplacePattern(b Unit, old Pattern, new Unit, n int) uint {
+	if n < 0 {
+		return b.value
+	}
+
+	result := uint(0)
+	for i := 0; i < b.leng; {
+		if n > 0 && MatchAt(b, old, i) {
+			result = result<<new.leng | new.value
+			n--
+			i += old.leng
+		} else {
+			result = result<<1 | GetBitAtIndex(b, i)
+			i++
+		}
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+package bitop
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePattern(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		s        string
+		expected Pattern
+		err      error
+	}{
+		{"empty", "", NewPattern(0, 0, 0), nil},
+		{"exact", "0101", NewPattern(0b0101, 0b1111, 4), nil},
+		{"wildcards", "10x1_xx0", NewPattern(0b1001000, 0b1101001, 7), nil},
+		{"question mark", "?1", NewPattern(0b01, 0b01, 2), nil},
+		{"invalid", "10z", Pattern{}, ErrSyntax},
+		{"underscore only", "_", Pattern{}, ErrSyntax},
+		{"double underscore", "1__0", Pattern{}, ErrSyntax},
+		{"trailing underscore", "10_", Pattern{}, ErrSyntax},
+		{"leading underscore", "_1", Pattern{}, ErrSyntax},
+		{"too long", "x0000000000000000000000000000000000000000000000000000000000000000", Pattern{}, ErrLengthOverflow},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := ParsePattern(tc.s)
+			if result != tc.expected || !errors.Is(err, tc.err) {
+				t.Fatalf("[TestParsePattern][%s]: Got %v %v, expected %v %v", tc.name, result, err, tc.expected, tc.err)
+			}
+		})
+	}
+
+	if p, _ := ParsePattern("10X1_??0"); p.String() != "10x1xx0" {
+		t.Fatalf("[TestParsePattern][string]: Got %v, expected %v", p, "10x1xx0")
+	}
+}
+
+func TestNewPattern(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		p        Pattern
+		expected string
+		b        Unit
+		matches  bool
+	}{
+		{"exact", NewPattern(0b10, 0b11, 2), "10", NewUnit(0b10, 2), true},
+		{"don't care", NewPattern(0b10, 0b10, 2), "1x", NewUnit(0b11, 2), true},
+		{"value wider than length", NewPattern(0b1111, 0b1111, 2), "11", NewUnit(0b11, 2), true},
+		{"value outside care", NewPattern(0b0111, 0b0101, 3), "1x1", NewUnit(0b101, 3), true},
+		{"mismatch", NewPattern(0b1110, 0b1111, 2), "10", NewUnit(0b11, 2), false},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if result := tc.p.String(); result != tc.expected {
+				t.Fatalf("[TestNewPattern][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			if parsed, _ := ParsePattern(tc.expected); tc.p != parsed {
+				t.Fatalf("[TestNewPattern][%s]: Got %+v, expected %+v", tc.name, tc.p, parsed)
+			}
+			if result := MatchAt(tc.b, tc.p, 0); result != tc.matches {
+				t.Fatalf("[TestNewPattern][%s]: Got %v, expected %v", tc.name, result, tc.matches)
+			}
+		})
+	}
+}
+
+func TestIndexPattern(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		p        string
+		first    int
+		last     int
+		contains bool
+	}{
+		{"exact", NewUnit(0b0110110, 7), "11", 1, 4, true},
+		{"wildcard", NewUnit(0b0110110, 7), "1x1", 2, 2, true},
+		{"all wildcards", NewUnit(0b0110110, 7), "xx", 0, 5, true},
+		{"no match", NewUnit(0b0110110, 7), "1xx1xx1", -1, -1, false},
+		{"leading zeroes", NewUnit(0b1, 6), "0x00", 0, 1, true},
+		{"longer pattern", NewUnit(0b11, 2), "xxx", -1, -1, false},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			p, _ := ParsePattern(tc.p)
+			if result := IndexPattern(tc.b, p); result != tc.first {
+				t.Fatalf("[TestIndexPattern][%s]: Got first %v, expected %v", tc.name, result, tc.first)
+			}
+			if result := LastIndexPattern(tc.b, p); result != tc.last {
+				t.Fatalf("[TestIndexPattern][%s]: Got last %v, expected %v", tc.name, result, tc.last)
+			}
+			if result := ContainsPattern(tc.b, p); result != tc.contains {
+				t.Fatalf("[TestIndexPattern][%s]: Got %v, expected %v", tc.name, result, tc.contains)
+			}
+		})
+	}
+}
+
+func TestReplacePattern(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		old      string
+		new      Unit
+		n        int
+		expected uint
+	}{
+		{"sync words", NewUnit(0b10011101, 8), "1xx1", NewUnit(0b0, 1), 2, 0b00},
+		{"limited", NewUnit(0b101101, 6), "1x", NewUnit(0b00, 2), 1, 0b001101},
+		{"no match", NewUnit(0b0000, 4), "1x", NewUnit(0b11, 2), 2, 0b0000},
+		{"negative n", NewUnit(0b1111, 4), "1", NewUnit(0b0, 1), -1, 0b1111},
+		{"empty pattern", NewUnit(0b101, 3), "", NewUnit(0b11, 2), 2, 0b1111101},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			p, _ := ParsePattern(tc.old)
+			result := ReplacePattern(tc.b, p, tc.new, tc.n)
+			if result != tc.expected {
+				t.Fatalf("[TestReplacePattern][%s]: Got %02b, expected %02b", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
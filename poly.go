@@ -0,0 +1,172 @@
+package bitop
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// ErrDivisionByZero is returned when dividing by, or reducing modulo, the zero polynomial
+var ErrDivisionByZero = errors.New("bitop: division by zero polynomial")
+
+// The functions below treat a Unit as a polynomial over GF(2), bit i from the right being the coefficient of x^i
+// Results are as long as their degree requires, or as the degree of the modulus for results reduced by one
+
+// Degree returns the degree of the polynomial, the zero polynomial has degree -1
+func Degree(p Unit) int {
+	return bits.Len(p.value&mask(p.leng)) - 1
+}
+
+// PolyMul returns the carry-less product of two polynomials
+// Returns ErrLengthOverflow if the degree of the product does not fit in a uint
+func PolyMul(a, b Unit) (Unit, error) {
+	da, db := Degree(a), Degree(b)
+	if da < 0 || db < 0 {
+		return Unit{}, nil
+	}
+	if da+db >= bits.UintSize {
+		return Unit{}, fmt.Errorf("%w: product of degree %d", ErrLengthOverflow, da+db)
+	}
+	return Unit{value: clmul(a.value&mask(a.leng), b.value&mask(b.leng)), leng: da + db + 1}, nil
+}
+
+// PolyDivMod returns the quotient and remainder of dividing a by b, the remainder has the length of the degree of b
+// Returns ErrDivisionByZero if b is zero
+func PolyDivMod(a, b Unit) (q, r Unit, err error) {
+	db := Degree(b)
+	if db < 0 {
+		return Unit{}, Unit{}, ErrDivisionByZero
+	}
+	qv, rv := polyDivMod(a.value&mask(a.leng), b.value&mask(b.leng))
+	return Unit{value: qv, leng: max(Degree(a)-db+1, 0)}, Unit{value: rv, leng: db}, nil
+}
+
+// PolyGCD returns the greatest common divisor of two polynomials, zero if both are zero
+func PolyGCD(a, b Unit) Unit {
+	g := polyGCD(a.value&mask(a.leng), b.value&mask(b.leng))
+	return Unit{value: g, leng: bits.Len(g)}
+}
+
+// PolyMulMod returns the product of a and b modulo m, the result has the length of the degree of m
+// Returns ErrDivisionByZero if m is zero
+func PolyMulMod(a, b, m Unit) (Unit, error) {
+	dm := Degree(m)
+	if dm < 0 {
+		return Unit{}, ErrDivisionByZero
+	}
+	mv := m.value & mask(m.leng)
+	_, av := polyDivMod(a.value&mask(a.leng), mv)
+	_, bv := polyDivMod(b.value&mask(b.leng), mv)
+	return Unit{value: mulMod(av, bv, mv), leng: dm}, nil
+}
+
+// PolyPowMod returns a raised to the power e modulo m by repeated squaring, the result has the length of the degree of m
+// Returns ErrDivisionByZero if m is zero
+func PolyPowMod(a Unit, e uint, m Unit) (Unit, error) {
+	dm := Degree(m)
+	if dm < 0 {
+		return Unit{}, ErrDivisionByZero
+	}
+	mv := m.value & mask(m.leng)
+	_, av := polyDivMod(a.value&mask(a.leng), mv)
+	return Unit{value: powMod(av, e, mv), leng: dm}, nil
+}
+
+// IsIrreducible returns true if the polynomial has degree at least one and no divisors other than one and itself
+// It uses Rabin's test: x^(2^n) = x modulo p, and x^(2^(n/q)) - x is coprime with p for each prime q dividing the degree n
+func IsIrreducible(p Unit) bool {
+	pv := p.value & mask(p.leng)
+	n := bits.Len(pv) - 1
+	if n < 1 {
+		return false
+	}
+	_, x := polyDivMod(0b10, pv)
+	for _, q := range primeFactors(n) {
+		if polyGCD(frobenius(x, n/q, pv)^x, pv) != 1 {
+			return false
+		}
+	}
+	return frobenius(x, n, pv) == x
+}
+
+// clmul returns the carry-less product of a and b, which must fit in a uint
+func clmul(a, b uint) uint {
+	var p uint
+	for ; b != 0; b &= b - 1 {
+		p ^= a << bits.TrailingZeros(b)
+	}
+	return p
+}
+
+// polyDivMod divides a by the non-zero polynomial b
+func polyDivMod(a, b uint) (q, r uint) {
+	db := bits.Len(b) - 1
+	for d := bits.Len(a) - 1; d >= db; d = bits.Len(a) - 1 {
+		q |= 1 << (d - db)
+		a ^= b << (d - db)
+	}
+	return q, a
+}
+
+// polyGCD returns the greatest common divisor of a and b by Euclid's algorithm
+func polyGCD(a, b uint) uint {
+	for b != 0 {
+		_, r := polyDivMod(a, b)
+		a, b = b, r
+	}
+	return a
+}
+
+// mulMod returns a·b modulo m, a and b must already be reduced modulo m
+// The product is built one bit of b at a time so it never needs more than a uint
+func mulMod(a, b, m uint) uint {
+	top := uint(1) << (bits.Len(m) - 1)
+	var p uint
+	for i := bits.Len(b) - 1; i >= 0; i-- {
+		p <<= 1
+		if p&top != 0 {
+			p ^= m
+		}
+		if b>>i&1 == 1 {
+			p ^= a
+		}
+	}
+	return p
+}
+
+// powMod returns a^e modulo m, a must already be reduced modulo m
+func powMod(a, e, m uint) uint {
+	_, p := polyDivMod(1, m)
+	for ; e != 0; e >>= 1 {
+		if e&1 == 1 {
+			p = mulMod(p, a, m)
+		}
+		a = mulMod(a, a, m)
+	}
+	return p
+}
+
+// frobenius returns a^(2^k) modulo m by squaring k times
+func frobenius(a uint, k int, m uint) uint {
+	for i := 0; i < k; i++ {
+		a = mulMod(a, a, m)
+	}
+	return a
+}
+
+// primeFactors returns the distinct prime factors of n in increasing order
+func primeFactors(n int) []int {
+	var ps []int
+	for q := 2; q*q <= n; q++ {
+		if n%q == 0 {
+			ps = append(ps, q)
+			for n%q == 0 {
+				n /= q
+			}
+		}
+	}
+	if n > 1 {
+		ps = append(ps, n)
+	}
+	return ps
+}
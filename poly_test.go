@@ -0,0 +1,219 @@
+package bitop
+
+import (
+	"errors"
+	"math/bits"
+	"math/rand"
+	"testing"
+)
+
+func TestDegree(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		p        Unit
+		expected int
+	}{
+		{"zero", NewUnit(0, 8), -1},
+		{"one", NewUnit(1, 1), 0},
+		{"leading zeroes", NewUnit(0b1011, 8), 3},
+		{"bits beyond length", NewUnit(0b1011, 2), 1},
+		{"full word", NewUnit(1<<63, 64), 63},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := Degree(tc.p)
+			if result != tc.expected {
+				t.Fatalf("[TestDegree][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestPolyMul(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		a        Unit
+		b        Unit
+		expected Unit
+		err      error
+	}{
+		{"zero", NewUnit(0, 4), NewUnit(0b101, 3), NewUnit(0, 0), nil},
+		{"square has no carries", NewUnit(0b11, 2), NewUnit(0b11, 2), NewUnit(0b101, 3), nil},
+		{"cubes", NewUnit(0b1011, 4), NewUnit(0b111, 3), NewUnit(0b110001, 6), nil},
+		{"full word", NewUnit(1<<62, 64), NewUnit(0b11, 2), NewUnit(0b11<<62, 64), nil},
+		{"overflow", NewUnit(1<<63, 64), NewUnit(0b10, 2), NewUnit(0, 0), ErrLengthOverflow},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := PolyMul(tc.a, tc.b)
+			if result != tc.expected || !errors.Is(err, tc.err) {
+				t.Fatalf("[TestPolyMul][%s]: Got %v (%v), expected %v (%v)", tc.name, result, err, tc.expected, tc.err)
+			}
+		})
+	}
+}
+
+func TestPolyDivMod(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name string
+		a    Unit
+		b    Unit
+		q    Unit
+		r    Unit
+		err  error
+	}{
+		{"exact", NewUnit(0b110001, 6), NewUnit(0b111, 3), NewUnit(0b1011, 4), NewUnit(0b00, 2), nil},
+		{"remainder", NewUnit(0b110011, 6), NewUnit(0b111, 3), NewUnit(0b1011, 4), NewUnit(0b10, 2), nil},
+		{"smaller dividend", NewUnit(0b11, 2), NewUnit(0b1011, 4), NewUnit(0, 0), NewUnit(0b011, 3), nil},
+		{"crc", NewUnit(0b11010011101100000, 17), NewUnit(0b1011, 4), NewUnit(0b11110001111100, 14), NewUnit(0b100, 3), nil},
+		{"division by zero", NewUnit(0b11, 2), NewUnit(0, 4), NewUnit(0, 0), NewUnit(0, 0), ErrDivisionByZero},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			q, r, err := PolyDivMod(tc.a, tc.b)
+			if q != tc.q || r != tc.r || !errors.Is(err, tc.err) {
+				t.Fatalf("[TestPolyDivMod][%s]: Got %v, %v (%v), expected %v, %v (%v)", tc.name, q, r, err, tc.q, tc.r, tc.err)
+			}
+		})
+	}
+}
+
+func TestPolyDivModRandom(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		a := NewUnit(uint(r.Uint64()), 64)
+		b := NewUnit(uint(r.Uint64())>>r.Intn(64)|1, 64)
+		q, rem, err := PolyDivMod(a, b)
+		if err != nil || Degree(rem) >= Degree(b) {
+			t.Fatalf("[TestPolyDivModRandom][%v / %v]: Got remainder %v (%v)", a, b, rem, err)
+		}
+		p, _ := PolyMul(q, b)
+		if p.Value()^rem.Value() != a.Value() {
+			t.Fatalf("[TestPolyDivModRandom][%v / %v]: Got q·b + r = %b", a, b, p.Value()^rem.Value())
+		}
+	}
+}
+
+func TestPolyGCD(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		a        Unit
+		b        Unit
+		expected Unit
+	}{
+		{"zeros", NewUnit(0, 4), NewUnit(0, 4), NewUnit(0, 0)},
+		{"one zero", NewUnit(0b1011, 4), NewUnit(0, 4), NewUnit(0b1011, 4)},
+		{"coprime", NewUnit(0b1011, 4), NewUnit(0b111, 3), NewUnit(1, 1)},
+		{"common factor", NewUnit(0b110001, 6), NewUnit(0b1001, 4), NewUnit(0b111, 3)},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := PolyGCD(tc.a, tc.b)
+			if result != tc.expected {
+				t.Fatalf("[TestPolyGCD][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestPolyMulMod(t *testing.T) {
+	t.Parallel()
+	aes := NewUnit(0x11B, 9)
+	for _, tc := range []struct {
+		name     string
+		a        Unit
+		b        Unit
+		m        Unit
+		expected Unit
+		err      error
+	}{
+		{"aes inverse", NewUnit(0x53, 8), NewUnit(0xCA, 8), aes, NewUnit(1, 8), nil},
+		{"aes product", NewUnit(0x57, 8), NewUnit(0x83, 8), aes, NewUnit(0xC1, 8), nil},
+		{"unreduced inputs", NewUnit(0x53^0x11B, 9), NewUnit(0xCA, 8), aes, NewUnit(1, 8), nil},
+		{"modulus one", NewUnit(0b11, 2), NewUnit(0b11, 2), NewUnit(1, 1), NewUnit(0, 0), nil},
+		{"full word modulus", NewUnit(1<<62, 63), NewUnit(0b10, 2), NewUnit(1<<63|0b11011, 64), NewUnit(0b11011, 63), nil},
+		{"division by zero", NewUnit(0b11, 2), NewUnit(0b11, 2), NewUnit(0, 0), NewUnit(0, 0), ErrDivisionByZero},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := PolyMulMod(tc.a, tc.b, tc.m)
+			if result != tc.expected || !errors.Is(err, tc.err) {
+				t.Fatalf("[TestPolyMulMod][%s]: Got %v (%v), expected %v (%v)", tc.name, result, err, tc.expected, tc.err)
+			}
+		})
+	}
+}
+
+func TestPolyPowMod(t *testing.T) {
+	t.Parallel()
+	aes := NewUnit(0x11B, 9)
+	for a := uint(1); a < 256; a++ {
+		inv, _ := PolyPowMod(NewUnit(a, 8), 254, aes)
+		p, _ := PolyMulMod(NewUnit(a, 8), inv, aes)
+		if p != NewUnit(1, 8) {
+			t.Fatalf("[TestPolyPowMod][%02x]: Got %v · %v = %v, expected %v", a, NewUnit(a, 8), inv, p, NewUnit(1, 8))
+		}
+	}
+	if result, _ := PolyPowMod(NewUnit(0b10, 2), 0, aes); result != NewUnit(1, 8) {
+		t.Fatalf("[TestPolyPowMod][zero power]: Got %v, expected %v", result, NewUnit(1, 8))
+	}
+	if _, err := PolyPowMod(NewUnit(0b10, 2), 3, NewUnit(0, 4)); !errors.Is(err, ErrDivisionByZero) {
+		t.Fatalf("[TestPolyPowMod][division by zero]: Got %v, expected %v", err, ErrDivisionByZero)
+	}
+}
+
+func TestIsIrreducible(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		p        Unit
+		expected bool
+	}{
+		{"zero", NewUnit(0, 4), false},
+		{"one", NewUnit(1, 1), false},
+		{"x", NewUnit(0b10, 2), true},
+		{"x + 1", NewUnit(0b11, 2), true},
+		{"square", NewUnit(0b101, 3), false},
+		{"x^2 + x + 1", NewUnit(0b111, 3), true},
+		{"aes", NewUnit(0x11B, 9), true},
+		{"square of irreducible", NewUnit(0b10101, 5), false},
+		{"crc-8", NewUnit(0x107, 9), false},
+		{"degree 64", NewUnit(0x1B, 64), false},
+		{"degree 63", NewUnit(1<<63|0b11, 64), true},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := IsIrreducible(tc.p)
+			if result != tc.expected {
+				t.Fatalf("[TestIsIrreducible][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestIsIrreducibleTrialDivision(t *testing.T) {
+	t.Parallel()
+	for p := uint(2); p < 1<<11; p++ {
+		expected := true
+		for d := uint(2); 2*(bits.Len(d)-1) <= bits.Len(p)-1; d++ {
+			if _, r := polyDivMod(p, d); r == 0 {
+				expected = false
+				break
+			}
+		}
+		if result := IsIrreducible(NewUnit(p, bits.Len(p))); result != expected {
+			t.Fatalf("[TestIsIrreducibleTrialDivision][%b]: Got %v, expected %v", p, result, expected)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package bitop
+
+import (
+	"math/bits"
+	"sort"
+)
+
+const (
+	// blockWords is the number of 64-bit words in each 512-bit block of the rank directory
+	blockWords = 8
+	// sampleRate is the number of ones, or zeros, between select samples
+	sampleRate = 512
+)
+
+// RankSelect is a read-only index over a bit string answering rank queries in constant time and select queries
+// in near-constant time. It uses the rank9 layout: per 512-bit block, one word holds the ones before the block and
+// another packs the 9-bit counts before each of its words, 25% extra space. Select samples the block of every 512th
+// one and every 512th zero in 32-bit entries, at most 6.25% more, and binary searches the blocks between two samples.
+// Bit strings are never modified in place, so the index shares the words of the bit string instead of copying them.
+type RankSelect struct {
+	words    []uint64
+	leng     int
+	ones     int
+	counts   []uint64 // per block, the ones before it followed by the packed counts within it
+	samples1 []uint32 // block holding the one of each multiple of sampleRate
+	samples0 []uint32 // block holding the zero of each multiple of sampleRate
+}
+
+// NewRankSelect builds the index over the bit string
+func NewRankSelect(s BitString) *RankSelect {
+	nblocks := (len(s.words) + blockWords - 1) / blockWords
+	rs := &RankSelect{words: s.words, leng: s.leng, counts: make([]uint64, 2*nblocks)}
+	for b := 0; b < nblocks; b++ {
+		rs.counts[2*b] = uint64(rs.ones)
+		inBlock := 0
+		for j := 0; j < blockWords; j++ {
+			if j > 0 {
+				rs.counts[2*b+1] |= uint64(inBlock) << (9 * (j - 1))
+			}
+			if w := b*blockWords + j; w < len(rs.words) {
+				inBlock += bits.OnesCount64(rs.words[w])
+			}
+		}
+		for k := (rs.ones + sampleRate - 1) / sampleRate * sampleRate; k < rs.ones+inBlock; k += sampleRate {
+			rs.samples1 = append(rs.samples1, uint32(b))
+		}
+		zeros, blockZeros := b*blockWords*64-rs.ones, blockWords*64-inBlock
+		for k := (zeros + sampleRate - 1) / sampleRate * sampleRate; k < zeros+blockZeros; k += sampleRate {
+			rs.samples0 = append(rs.samples0, uint32(b))
+		}
+		rs.ones += inBlock
+	}
+	return rs
+}
+
+// Len returns the number of bits indexed
+func (rs *RankSelect) Len() int {
+	return rs.leng
+}
+
+// Ones returns the total number of ones
+func (rs *RankSelect) Ones() int {
+	return rs.ones
+}
+
+// Rank1 returns the number of ones before index i, [0, i), i is clamped to [0, Len()]
+func (rs *RankSelect) Rank1(i int) int {
+	i = clamp(i, 0, rs.leng)
+	if i == rs.leng {
+		return rs.ones
+	}
+	w := i / 64
+	b := w / blockWords
+	return int(rs.counts[2*b]) + rs.inBlock(b, w%blockWords) + bits.OnesCount64(rs.words[w]>>uint(64-i%64))
+}
+
+// Rank0 returns the number of zeros before index i, [0, i), i is clamped to [0, Len()]
+func (rs *RankSelect) Rank0(i int) int {
+	i = clamp(i, 0, rs.leng)
+	return i - rs.Rank1(i)
+}
+
+// Select1 returns the index of the k-th one counting from zero, -1 if there are not more than k ones
+func (rs *RankSelect) Select1(k int) int {
+	if k < 0 || k >= rs.ones {
+		return -1
+	}
+	b := rs.searchBlocks(rs.samples1, k, func(b int) int { return int(rs.counts[2*b]) })
+	k -= int(rs.counts[2*b])
+	j := blockWords - 1
+	for rs.inBlock(b, j) > k {
+		j--
+	}
+	return (b*blockWords+j)*64 + selectInWord(rs.words[b*blockWords+j], k-rs.inBlock(b, j))
+}
+
+// Select0 returns the index of the k-th zero counting from zero, -1 if there are not more than k zeros
+func (rs *RankSelect) Select0(k int) int {
+	if k < 0 || k >= rs.leng-rs.ones {
+		return -1
+	}
+	b := rs.searchBlocks(rs.samples0, k, func(b int) int { return b*blockWords*64 - int(rs.counts[2*b]) })
+	k -= b*blockWords*64 - int(rs.counts[2*b])
+	j := blockWords - 1
+	for j*64-rs.inBlock(b, j) > k {
+		j--
+	}
+	return (b*blockWords+j)*64 + selectInWord(^rs.words[b*blockWords+j], k-(j*64-rs.inBlock(b, j)))
+}
+
+// inBlock returns the ones in block b before its word j
+func (rs *RankSelect) inBlock(b, j int) int {
+	if j == 0 {
+		return 0
+	}
+	return int(rs.counts[2*b+1]>>(9*(j-1))) & 0x1FF
+}
+
+// searchBlocks returns the last block with before(block) <= k, searching between the samples around k
+func (rs *RankSelect) searchBlocks(samples []uint32, k int, before func(b int) int) int {
+	lo, hi := int(samples[k/sampleRate]), len(rs.counts)/2
+	if k/sampleRate+1 < len(samples) {
+		hi = int(samples[k/sampleRate+1]) + 1
+	}
+	return lo + sort.Search(hi-lo, func(i int) bool { return before(lo+i) > k }) - 1
+}
+
+// selectInWord returns the index from the left of the k-th one in the word, counting from zero
+func selectInWord(w uint64, k int) int {
+	i := 0
+	for shift := 32; shift >= 8; shift /= 2 {
+		if n := bits.OnesCount64(w >> uint(64-shift)); n <= k {
+			k -= n
+			w <<= uint(shift)
+			i += shift
+		}
+	}
+	for ; ; w <<= 1 {
+		if w>>63 == 1 {
+			if k == 0 {
+				return i
+			}
+			k--
+		}
+		i++
+	}
+}
@@ -0,0 +1,117 @@
+package bitop
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// randomBitString returns a bit string of length leng where each bit is one with the given probability
+func randomBitString(rng *rand.Rand, leng int, density float64) BitString {
+	var b Builder
+	for i := 0; i < leng; i++ {
+		if rng.Float64() < density {
+			b.WriteBit(1)
+		} else {
+			b.WriteBit(0)
+		}
+	}
+	return b.BitString()
+}
+
+func TestRankSelect(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(1))
+	for _, tc := range []struct {
+		name    string
+		leng    int
+		density float64
+	}{
+		{"empty", 0, 0.5},
+		{"short", 13, 0.5},
+		{"zeroes", 1000, 0},
+		{"ones", 1000, 1},
+		{"sparse", 5000, 0.01},
+		{"dense", 5000, 0.9},
+		{"superblock boundary", 1024, 0.5},
+		{"many samples", 100000, 0.5},
+		{"long gaps between samples", 200000, 0.003},
+		{"few zeros", 100000, 0.995},
+	} {
+		s := randomBitString(rng, tc.leng, tc.density)
+		rs := NewRankSelect(s)
+		ones, zeros := 0, 0
+		for i := 0; i <= s.Len(); i++ {
+			if r := rs.Rank1(i); r != ones {
+				t.Fatalf("[TestRankSelect][%s]: Got Rank1(%d) = %v, expected %v", tc.name, i, r, ones)
+			}
+			if r := rs.Rank0(i); r != zeros {
+				t.Fatalf("[TestRankSelect][%s]: Got Rank0(%d) = %v, expected %v", tc.name, i, r, zeros)
+			}
+			if i == s.Len() {
+				break
+			}
+			if s.GetBitAtIndex(i) == 1 {
+				if p := rs.Select1(ones); p != i {
+					t.Fatalf("[TestRankSelect][%s]: Got Select1(%d) = %v, expected %v", tc.name, ones, p, i)
+				}
+				ones++
+			} else {
+				if p := rs.Select0(zeros); p != i {
+					t.Fatalf("[TestRankSelect][%s]: Got Select0(%d) = %v, expected %v", tc.name, zeros, p, i)
+				}
+				zeros++
+			}
+		}
+		words := len(s.words)
+		if extra := len(rs.counts)*64 + (len(rs.samples1)+len(rs.samples0))*32; extra > words*64*5/16+256 {
+			t.Fatalf("[TestRankSelect][%s]: Got %d bits of index over %d bits, expected at most 31.25%%", tc.name, extra, words*64)
+		}
+		if rs.Ones() != ones || rs.Select1(ones) != -1 || rs.Select0(zeros) != -1 || rs.Select1(-1) != -1 {
+			t.Fatalf("[TestRankSelect][%s]: out of range queries did not return -1", tc.name)
+		}
+	}
+}
+
+func BenchmarkRank1(b *testing.B) {
+	rs := NewRankSelect(randomBitString(rand.New(rand.NewSource(1)), 1<<16, 0.5))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rs.Rank1(i & (1<<16 - 1))
+	}
+}
+
+func BenchmarkRank1Naive(b *testing.B) {
+	s := randomBitString(rand.New(rand.NewSource(1)), 1<<16, 0.5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		n := 0
+		for j := 0; j < i&(1<<16-1); j++ {
+			n += int(s.GetBitAtIndex(j))
+		}
+	}
+}
+
+func BenchmarkSelect1(b *testing.B) {
+	rs := NewRankSelect(randomBitString(rand.New(rand.NewSource(1)), 1<<16, 0.5))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rs.Select1(i % rs.Ones())
+	}
+}
+
+func BenchmarkSelect1Naive(b *testing.B) {
+	s := randomBitString(rand.New(rand.NewSource(1)), 1<<16, 0.5)
+	ones := NewRankSelect(s).Ones()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k := i % ones
+		for j := 0; j < s.Len(); j++ {
+			if s.GetBitAtIndex(j) == 1 {
+				if k == 0 {
+					break
+				}
+				k--
+			}
+		}
+	}
+}
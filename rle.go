@@ -0,0 +1,81 @@
+package bitop
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// ErrInvalidRLE is returned by DecodeRLE when the data was not produced by EncodeRLE
+var ErrInvalidRLE = errors.New("bitop: invalid run-length encoding")
+
+// Run is a section of consecutive identical bits
+type Run struct {
+	Bit    uint
+	Length int
+}
+
+// Runs returns the runs of identical bits in the binary, from left to right
+func Runs(b Unit) []Run {
+	var runs []Run
+	for i := 0; i < b.leng; i++ {
+		bit := GetBitAtIndex(b, i)
+		if n := len(runs); n > 0 && runs[n-1].Bit == bit {
+			runs[n-1].Length++
+		} else {
+			runs = append(runs, Run{Bit: bit, Length: 1})
+		}
+	}
+	return runs
+}
+
+// LongestRun returns the length of the longest run of the given bit, zero if the bit does not occur
+func LongestRun(b Unit, bit uint) int {
+	longest := 0
+	for _, r := range Runs(b) {
+		if r.Bit == bit && r.Length > longest {
+			longest = r.Length
+		}
+	}
+	return longest
+}
+
+// EncodeRLE returns the run-length encoding of the binary
+// The first byte holds the first bit, followed by the length of each run as a uvarint, runs alternating between bits
+func EncodeRLE(b Unit) []byte {
+	runs := Runs(b)
+	data := []byte{0}
+	if len(runs) > 0 {
+		data[0] = byte(runs[0].Bit)
+	}
+	for _, r := range runs {
+		data = binary.AppendUvarint(data, uint64(r.Length))
+	}
+	return data
+}
+
+// DecodeRLE returns the binary of the run-length encoding written by EncodeRLE
+// Malformed data returns ErrInvalidRLE, and runs longer than a uint in total return ErrLengthOverflow
+func DecodeRLE(data []byte) (Unit, error) {
+	if len(data) == 0 || data[0] > 1 {
+		return Unit{}, fmt.Errorf("%w: missing first bit", ErrInvalidRLE)
+	}
+	bit := uint(data[0])
+	b := Unit{}
+	for data = data[1:]; len(data) > 0; bit ^= 1 {
+		n, k := binary.Uvarint(data)
+		if k <= 0 || n == 0 {
+			return Unit{}, fmt.Errorf("%w: invalid run length", ErrInvalidRLE)
+		}
+		if n > uint64(bits.UintSize-b.leng) {
+			return Unit{}, fmt.Errorf("%w: runs longer than %d bits", ErrLengthOverflow, bits.UintSize)
+		}
+		b = ShiftLeftGrow(b, int(n))
+		if bit == 1 {
+			b.value |= mask(int(n))
+		}
+		data = data[k:]
+	}
+	return b, nil
+}
@@ -0,0 +1,120 @@
+package bitop
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRuns(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		expected []Run
+	}{
+		{
+			name:     "empty",
+			b:        NewUnit(0, 0),
+			expected: nil,
+		},
+		{
+			name:     "zeroes",
+			b:        NewUnit(0b0000, 4),
+			expected: []Run{{Bit: 0, Length: 4}},
+		},
+		{
+			name:     "mixed",
+			b:        NewUnit(0b0011101, 7),
+			expected: []Run{{0, 2}, {1, 3}, {0, 1}, {1, 1}},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := Runs(tc.b)
+			if len(result) != len(tc.expected) {
+				t.Fatalf("[TestRuns][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			for i, r := range result {
+				if r != tc.expected[i] {
+					t.Fatalf("[TestRuns][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestLongestRun(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		bit      uint
+		expected int
+	}{
+		{"ones", NewUnit(0b0111011110, 10), 1, 4},
+		{"zeroes", NewUnit(0b0111011110, 10), 0, 1},
+		{"absent", NewUnit(0b111, 3), 0, 0},
+		{"leading zeroes", NewUnit(0b1, 8), 0, 7},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := LongestRun(tc.b, tc.bit)
+			if result != tc.expected {
+				t.Fatalf("[TestLongestRun][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestEncodeRLE(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		expected []byte
+	}{
+		{"empty", NewUnit(0, 0), []byte{0}},
+		{"leading zeroes", NewUnit(0b0011101, 7), []byte{0, 2, 3, 1, 1}},
+		{"ones", NewUnit(^uint(0), 64), []byte{1, 64}},
+		{"zeroes", NewUnit(0, 40), []byte{0, 40}},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := EncodeRLE(tc.b)
+			if string(result) != string(tc.expected) {
+				t.Fatalf("[TestEncodeRLE][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			decoded, err := DecodeRLE(result)
+			if err != nil || decoded != tc.b {
+				t.Fatalf("[TestEncodeRLE][%s]: Got %v %v, expected %v", tc.name, decoded, err, tc.b)
+			}
+		})
+	}
+}
+
+func TestDecodeRLEErrors(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{"empty", nil, ErrInvalidRLE},
+		{"bad first bit", []byte{2, 1}, ErrInvalidRLE},
+		{"zero run", []byte{0, 1, 0}, ErrInvalidRLE},
+		{"truncated varint", []byte{0, 0x80}, ErrInvalidRLE},
+		{"too long", []byte{1, 60, 5}, ErrLengthOverflow},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := DecodeRLE(tc.data)
+			if !errors.Is(err, tc.expected) {
+				t.Fatalf("[TestDecodeRLEErrors][%s]: Got %v, expected %v", tc.name, err, tc.expected)
+			}
+		})
+	}
+}
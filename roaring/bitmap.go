@@ -0,0 +1,196 @@
+// Package roaring implements a compressed bitmap of uint32 values in the style of Roaring bitmaps
+//
+// Values are grouped by their high 16 bits into chunks, and each chunk is stored in whichever container suits it:
+// a sorted array for sparse chunks, a 65536-bit bitmap for dense chunks, or a list of runs for chunks of long intervals.
+package roaring
+
+import (
+	"iter"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Bitmap is a compressed set of uint32 values, the zero value is an empty set ready to use
+type Bitmap struct {
+	keys       []uint16
+	containers []container
+}
+
+// New returns a bitmap holding the given values
+func New(values ...uint32) *Bitmap {
+	b := &Bitmap{}
+	for _, v := range values {
+		b.Add(v)
+	}
+	return b
+}
+
+// Add inserts x into the bitmap
+func (b *Bitmap) Add(x uint32) {
+	hi, lo := uint16(x>>16), uint16(x)
+	i, found := b.find(hi)
+	if !found {
+		b.keys = append(b.keys, 0)
+		copy(b.keys[i+1:], b.keys[i:])
+		b.keys[i] = hi
+		b.containers = append(b.containers, nil)
+		copy(b.containers[i+1:], b.containers[i:])
+		b.containers[i] = &arrayContainer{}
+	}
+	b.containers[i] = b.containers[i].add(lo)
+}
+
+// Remove deletes x from the bitmap
+func (b *Bitmap) Remove(x uint32) {
+	hi, lo := uint16(x>>16), uint16(x)
+	i, found := b.find(hi)
+	if !found {
+		return
+	}
+	b.containers[i] = b.containers[i].remove(lo)
+	if b.containers[i].cardinality() == 0 {
+		b.keys = append(b.keys[:i], b.keys[i+1:]...)
+		b.containers = append(b.containers[:i], b.containers[i+1:]...)
+	}
+}
+
+// Contains returns true if x is in the bitmap
+func (b *Bitmap) Contains(x uint32) bool {
+	i, found := b.find(uint16(x >> 16))
+	return found && b.containers[i].contains(uint16(x))
+}
+
+// Cardinality returns the number of values in the bitmap
+func (b *Bitmap) Cardinality() uint64 {
+	n := uint64(0)
+	for _, c := range b.containers {
+		n += uint64(c.cardinality())
+	}
+	return n
+}
+
+// IsEmpty returns true if the bitmap holds no values
+func (b *Bitmap) IsEmpty() bool {
+	return len(b.containers) == 0
+}
+
+// And returns a new bitmap of the values in both bitmaps
+func (b *Bitmap) And(o *Bitmap) *Bitmap {
+	r := &Bitmap{}
+	for i, j := 0, 0; i < len(b.keys) && j < len(o.keys); {
+		switch {
+		case b.keys[i] < o.keys[j]:
+			i++
+		case b.keys[i] > o.keys[j]:
+			j++
+		default:
+			r.append(b.keys[i], and(b.containers[i], o.containers[j]))
+			i, j = i+1, j+1
+		}
+	}
+	return r
+}
+
+// Or returns a new bitmap of the values in either bitmap
+func (b *Bitmap) Or(o *Bitmap) *Bitmap {
+	r := &Bitmap{}
+	i, j := 0, 0
+	for i < len(b.keys) || j < len(o.keys) {
+		switch {
+		case j == len(o.keys) || i < len(b.keys) && b.keys[i] < o.keys[j]:
+			r.append(b.keys[i], clone(b.containers[i]))
+			i++
+		case i == len(b.keys) || o.keys[j] < b.keys[i]:
+			r.append(o.keys[j], clone(o.containers[j]))
+			j++
+		default:
+			r.append(b.keys[i], or(b.containers[i], o.containers[j]))
+			i, j = i+1, j+1
+		}
+	}
+	return r
+}
+
+// AndNot returns a new bitmap of the values in b but not in o
+func (b *Bitmap) AndNot(o *Bitmap) *Bitmap {
+	r := &Bitmap{}
+	for i, j := 0, 0; i < len(b.keys); {
+		switch {
+		case j == len(o.keys) || b.keys[i] < o.keys[j]:
+			r.append(b.keys[i], clone(b.containers[i]))
+			i++
+		case b.keys[i] > o.keys[j]:
+			j++
+		default:
+			r.append(b.keys[i], andNot(b.containers[i], o.containers[j]))
+			i, j = i+1, j+1
+		}
+	}
+	return r
+}
+
+// RunOptimize converts each chunk to its smallest container, using run containers for chunks made of long intervals
+func (b *Bitmap) RunOptimize() {
+	for i, c := range b.containers {
+		b.containers[i] = optimize(c)
+	}
+}
+
+// Equal returns true if both bitmaps hold the same values
+func (b *Bitmap) Equal(o *Bitmap) bool {
+	if len(b.keys) != len(o.keys) {
+		return false
+	}
+	for i, key := range b.keys {
+		x, y := b.containers[i], o.containers[i]
+		if o.keys[i] != key || x.cardinality() != y.cardinality() {
+			return false
+		}
+		if c := and(x, y); c == nil || c.cardinality() != x.cardinality() {
+			return false
+		}
+	}
+	return true
+}
+
+// All returns an iterator over the values in increasing order
+func (b *Bitmap) All() iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		for i, c := range b.containers {
+			hi := uint32(b.keys[i]) << 16
+			if !c.iterate(func(lo uint16) bool { return yield(hi | uint32(lo)) }) {
+				return
+			}
+		}
+	}
+}
+
+// String returns the values in increasing order, e.g. {1 3 5}
+func (b *Bitmap) String() string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for v := range b.All() {
+		if sb.Len() > 1 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(strconv.FormatUint(uint64(v), 10))
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// find returns the position of the chunk with the given high bits, or where it would be inserted
+func (b *Bitmap) find(hi uint16) (int, bool) {
+	i := sort.Search(len(b.keys), func(i int) bool { return b.keys[i] >= hi })
+	return i, i < len(b.keys) && b.keys[i] == hi
+}
+
+// append adds a chunk after all existing chunks, skipping empty containers
+func (b *Bitmap) append(key uint16, c container) {
+	if c == nil {
+		return
+	}
+	b.keys = append(b.keys, key)
+	b.containers = append(b.containers, c)
+}
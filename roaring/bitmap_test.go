@@ -0,0 +1,178 @@
+package roaring
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// model is the naive reference a Bitmap is checked against
+type model map[uint32]bool
+
+func (m model) sorted() []uint32 {
+	values := make([]uint32, 0, len(m))
+	for v := range m {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+// randomBitmap fills a bitmap and its model with values drawn from a mix of sparse, dense and run-like chunks
+func randomBitmap(rng *rand.Rand, n int) (*Bitmap, model) {
+	b, m := New(), model{}
+	for i := 0; i < n; i++ {
+		var v uint32
+		switch rng.Intn(3) {
+		case 0:
+			v = rng.Uint32()
+		case 1:
+			v = 3<<16 | uint32(rng.Intn(1<<16))
+		default:
+			v = 7<<16 | uint32(rng.Intn(1000))
+		}
+		b.Add(v)
+		m[v] = true
+	}
+	return b, m
+}
+
+// check compares a bitmap to its model through every read method
+func check(t *testing.T, name string, b *Bitmap, m model) {
+	t.Helper()
+	if b.Cardinality() != uint64(len(m)) {
+		t.Fatalf("[%s]: Got cardinality %v, expected %v", name, b.Cardinality(), len(m))
+	}
+	if result, expected := slices.Collect(b.All()), m.sorted(); !slices.Equal(result, expected) {
+		t.Fatalf("[%s]: Got %v values, expected %v", name, len(result), len(expected))
+	}
+	for v := range m {
+		if !b.Contains(v) {
+			t.Fatalf("[%s]: Got %v missing", name, v)
+		}
+	}
+	if b.IsEmpty() != (len(m) == 0) {
+		t.Fatalf("[%s]: Got IsEmpty %v for %v values", name, b.IsEmpty(), len(m))
+	}
+}
+
+func TestBitmapAddRemove(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(1))
+	b, m := New(), model{}
+	for i := 0; i < 50000; i++ {
+		v := uint32(rng.Intn(3))<<16 | uint32(rng.Intn(8000))
+		if rng.Intn(4) == 0 {
+			b.Remove(v)
+			delete(m, v)
+		} else {
+			b.Add(v)
+			m[v] = true
+		}
+		if b.Contains(v) != m[v] {
+			t.Fatalf("[TestBitmapAddRemove]: Got Contains(%v) = %v, expected %v", v, b.Contains(v), m[v])
+		}
+	}
+	check(t, "TestBitmapAddRemove", b, m)
+
+	for v := range m {
+		b.Remove(v)
+	}
+	check(t, "TestBitmapAddRemove][emptied", b, model{})
+}
+
+func TestBitmapSetOperations(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(2))
+	for round := 0; round < 10; round++ {
+		a, ma := randomBitmap(rng, rng.Intn(20000))
+		b, mb := randomBitmap(rng, rng.Intn(20000))
+		if round%2 == 1 {
+			a.RunOptimize()
+			check(t, "TestBitmapSetOperations][optimized", a, ma)
+		}
+
+		and, or, andNot := model{}, model{}, model{}
+		for v := range ma {
+			or[v] = true
+			if mb[v] {
+				and[v] = true
+			} else {
+				andNot[v] = true
+			}
+		}
+		for v := range mb {
+			or[v] = true
+		}
+
+		check(t, "TestBitmapSetOperations][And", a.And(b), and)
+		check(t, "TestBitmapSetOperations][Or", a.Or(b), or)
+		check(t, "TestBitmapSetOperations][AndNot", a.AndNot(b), andNot)
+		check(t, "TestBitmapSetOperations][inputs unchanged", a, ma)
+		if !a.Or(b).Equal(b.Or(a)) || a.Equal(b) && len(ma) != len(mb) {
+			t.Fatalf("[TestBitmapSetOperations]: Equal disagrees with the model")
+		}
+	}
+}
+
+func TestBitmapRunOptimize(t *testing.T) {
+	t.Parallel()
+	b, m := New(), model{}
+	for v := uint32(100); v < 60000; v++ {
+		b.Add(v)
+		m[v] = true
+	}
+	b.Add(1 << 20)
+	m[1<<20] = true
+	b.RunOptimize()
+	if _, ok := b.containers[0].(*runContainer); !ok {
+		t.Fatalf("[TestBitmapRunOptimize]: Got %T, expected *runContainer", b.containers[0])
+	}
+	if _, ok := b.containers[1].(*arrayContainer); !ok {
+		t.Fatalf("[TestBitmapRunOptimize]: Got %T, expected *arrayContainer", b.containers[1])
+	}
+	check(t, "TestBitmapRunOptimize", b, m)
+
+	b.Add(50)
+	b.Remove(200)
+	m[50] = true
+	delete(m, 200)
+	check(t, "TestBitmapRunOptimize][modified", b, m)
+}
+
+func TestBitmapSerialization(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(3))
+	for round := 0; round < 5; round++ {
+		b, m := randomBitmap(rng, rng.Intn(20000))
+		for v := uint32(9 << 16); v < 9<<16+5000; v++ {
+			b.Add(v)
+			m[v] = true
+		}
+		if round%2 == 0 {
+			b.RunOptimize()
+		}
+		data, err := b.MarshalBinary()
+		if err != nil {
+			t.Fatalf("[TestBitmapSerialization]: Got %v, expected nil", err)
+		}
+		var decoded Bitmap
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("[TestBitmapSerialization]: Got %v, expected nil", err)
+		}
+		check(t, "TestBitmapSerialization", &decoded, m)
+
+		for _, bad := range [][]byte{nil, data[:len(data)-1], append(data, 0), append([]byte("XXXX"), data[4:]...)} {
+			if err := decoded.UnmarshalBinary(bad); err != ErrInvalidFormat {
+				t.Fatalf("[TestBitmapSerialization]: Got %v for corrupt data, expected %v", err, ErrInvalidFormat)
+			}
+		}
+	}
+
+	data, _ := New().MarshalBinary()
+	var empty Bitmap
+	if err := empty.UnmarshalBinary(data); err != nil || !empty.IsEmpty() {
+		t.Fatalf("[TestBitmapSerialization][empty]: Got %v %v, expected empty bitmap", empty.String(), err)
+	}
+}
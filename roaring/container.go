@@ -0,0 +1,333 @@
+package roaring
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// arrayMaxSize is the largest cardinality kept in an array container, beyond it a bitmap container is smaller
+const arrayMaxSize = 4096
+
+// container holds the low 16 bits of the values sharing the same high 16 bits
+// add and remove may return a different kind of container when the current one is no longer the right fit
+type container interface {
+	add(x uint16) container
+	remove(x uint16) container
+	contains(x uint16) bool
+	cardinality() int
+	iterate(yield func(uint16) bool) bool
+	toBitmap() *bitmapContainer
+}
+
+// arrayContainer holds a sorted array of values, used for sparse chunks
+type arrayContainer struct {
+	values []uint16
+}
+
+func (a *arrayContainer) search(x uint16) int {
+	return sort.Search(len(a.values), func(i int) bool { return a.values[i] >= x })
+}
+
+func (a *arrayContainer) add(x uint16) container {
+	i := a.search(x)
+	if i < len(a.values) && a.values[i] == x {
+		return a
+	}
+	if len(a.values) >= arrayMaxSize {
+		return a.toBitmap().add(x)
+	}
+	a.values = append(a.values, 0)
+	copy(a.values[i+1:], a.values[i:])
+	a.values[i] = x
+	return a
+}
+
+func (a *arrayContainer) remove(x uint16) container {
+	i := a.search(x)
+	if i < len(a.values) && a.values[i] == x {
+		a.values = append(a.values[:i], a.values[i+1:]...)
+	}
+	return a
+}
+
+func (a *arrayContainer) contains(x uint16) bool {
+	i := a.search(x)
+	return i < len(a.values) && a.values[i] == x
+}
+
+func (a *arrayContainer) cardinality() int {
+	return len(a.values)
+}
+
+func (a *arrayContainer) iterate(yield func(uint16) bool) bool {
+	for _, v := range a.values {
+		if !yield(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *arrayContainer) toBitmap() *bitmapContainer {
+	bm := &bitmapContainer{}
+	for _, v := range a.values {
+		bm.add(v)
+	}
+	return bm
+}
+
+// bitmapContainer holds one bit for each of the 65536 possible values, used for dense chunks
+type bitmapContainer struct {
+	words [1024]uint64
+	card  int
+}
+
+func (bm *bitmapContainer) add(x uint16) container {
+	if !bm.contains(x) {
+		bm.words[x/64] |= 1 << (x % 64)
+		bm.card++
+	}
+	return bm
+}
+
+func (bm *bitmapContainer) remove(x uint16) container {
+	if bm.contains(x) {
+		bm.words[x/64] &^= 1 << (x % 64)
+		bm.card--
+	}
+	if bm.card <= arrayMaxSize {
+		return bm.toArray()
+	}
+	return bm
+}
+
+func (bm *bitmapContainer) contains(x uint16) bool {
+	return bm.words[x/64]>>(x%64)&1 == 1
+}
+
+func (bm *bitmapContainer) cardinality() int {
+	return bm.card
+}
+
+func (bm *bitmapContainer) iterate(yield func(uint16) bool) bool {
+	for i, w := range bm.words {
+		for w != 0 {
+			if !yield(uint16(i*64 + bits.TrailingZeros64(w))) {
+				return false
+			}
+			w &= w - 1
+		}
+	}
+	return true
+}
+
+func (bm *bitmapContainer) toBitmap() *bitmapContainer {
+	c := *bm
+	return &c
+}
+
+// toArray returns the values as an array container
+func (bm *bitmapContainer) toArray() *arrayContainer {
+	a := &arrayContainer{values: make([]uint16, 0, bm.card)}
+	bm.iterate(func(v uint16) bool {
+		a.values = append(a.values, v)
+		return true
+	})
+	return a
+}
+
+// countCard recomputes the cardinality from the words
+func (bm *bitmapContainer) countCard() {
+	bm.card = 0
+	for _, w := range bm.words {
+		bm.card += bits.OnesCount64(w)
+	}
+}
+
+// run is an interval of consecutive values [start, last]
+type run struct {
+	start, last uint16
+}
+
+// runContainer holds sorted, non-overlapping intervals of values, used for chunks made of long runs
+// It is only created by RunOptimize and unmarshaling, adding or removing converts it back to an array or bitmap
+type runContainer struct {
+	runs []run
+}
+
+func (r *runContainer) add(x uint16) container {
+	if r.contains(x) {
+		return r
+	}
+	return fit(r.toBitmap()).add(x)
+}
+
+func (r *runContainer) remove(x uint16) container {
+	if !r.contains(x) {
+		return r
+	}
+	return r.toBitmap().remove(x)
+}
+
+func (r *runContainer) contains(x uint16) bool {
+	i := sort.Search(len(r.runs), func(i int) bool { return r.runs[i].last >= x })
+	return i < len(r.runs) && r.runs[i].start <= x
+}
+
+func (r *runContainer) cardinality() int {
+	n := 0
+	for _, rn := range r.runs {
+		n += int(rn.last-rn.start) + 1
+	}
+	return n
+}
+
+func (r *runContainer) iterate(yield func(uint16) bool) bool {
+	for _, rn := range r.runs {
+		for v := int(rn.start); v <= int(rn.last); v++ {
+			if !yield(uint16(v)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r *runContainer) toBitmap() *bitmapContainer {
+	bm := &bitmapContainer{}
+	for _, rn := range r.runs {
+		for v := int(rn.start); v <= int(rn.last); v++ {
+			bm.words[v/64] |= 1 << uint(v%64)
+		}
+	}
+	bm.countCard()
+	return bm
+}
+
+// fit returns the bitmap as an array container if it is small enough
+func fit(bm *bitmapContainer) container {
+	if bm.card <= arrayMaxSize {
+		return bm.toArray()
+	}
+	return bm
+}
+
+// optimize returns the smallest representation of the container, trying run containers as well
+func optimize(c container) container {
+	var runs []run
+	c.iterate(func(v uint16) bool {
+		if n := len(runs); n > 0 && runs[n-1].last+1 == v {
+			runs[n-1].last = v
+		} else {
+			runs = append(runs, run{v, v})
+		}
+		return true
+	})
+	card := c.cardinality()
+	runSize, arraySize, bitmapSize := 2+4*len(runs), 2+2*card, 8192
+	if card > arrayMaxSize {
+		arraySize = bitmapSize + 1
+	}
+	switch {
+	case runSize < arraySize && runSize < bitmapSize:
+		return &runContainer{runs: runs}
+	case arraySize <= bitmapSize:
+		if a, ok := c.(*arrayContainer); ok {
+			return a
+		}
+		return c.toBitmap().toArray()
+	default:
+		return c.toBitmap()
+	}
+}
+
+// and returns the values in both containers, nil if there are none
+func and(a, b container) container {
+	if _, ok := b.(*arrayContainer); ok {
+		a, b = b, a
+	}
+	if x, ok := a.(*arrayContainer); ok {
+		r := &arrayContainer{}
+		for _, v := range x.values {
+			if b.contains(v) {
+				r.values = append(r.values, v)
+			}
+		}
+		return nonEmpty(r)
+	}
+	bm, other := a.toBitmap(), b.toBitmap()
+	for i := range bm.words {
+		bm.words[i] &= other.words[i]
+	}
+	bm.countCard()
+	return nonEmpty(fit(bm))
+}
+
+// or returns the values in either container
+func or(a, b container) container {
+	x, xok := a.(*arrayContainer)
+	y, yok := b.(*arrayContainer)
+	if xok && yok && len(x.values)+len(y.values) <= arrayMaxSize {
+		r := &arrayContainer{values: make([]uint16, 0, len(x.values)+len(y.values))}
+		i, j := 0, 0
+		for i < len(x.values) || j < len(y.values) {
+			switch {
+			case j == len(y.values) || i < len(x.values) && x.values[i] < y.values[j]:
+				r.values = append(r.values, x.values[i])
+				i++
+			case i == len(x.values) || y.values[j] < x.values[i]:
+				r.values = append(r.values, y.values[j])
+				j++
+			default:
+				r.values = append(r.values, x.values[i])
+				i, j = i+1, j+1
+			}
+		}
+		return r
+	}
+	bm, other := a.toBitmap(), b.toBitmap()
+	for i := range bm.words {
+		bm.words[i] |= other.words[i]
+	}
+	bm.countCard()
+	return fit(bm)
+}
+
+// andNot returns the values in a but not in b, nil if there are none
+func andNot(a, b container) container {
+	if x, ok := a.(*arrayContainer); ok {
+		r := &arrayContainer{}
+		for _, v := range x.values {
+			if !b.contains(v) {
+				r.values = append(r.values, v)
+			}
+		}
+		return nonEmpty(r)
+	}
+	bm, other := a.toBitmap(), b.toBitmap()
+	for i := range bm.words {
+		bm.words[i] &^= other.words[i]
+	}
+	bm.countCard()
+	return nonEmpty(fit(bm))
+}
+
+// clone returns a deep copy of the container
+func clone(c container) container {
+	switch c := c.(type) {
+	case *arrayContainer:
+		return &arrayContainer{values: append([]uint16(nil), c.values...)}
+	case *runContainer:
+		return &runContainer{runs: append([]run(nil), c.runs...)}
+	default:
+		return c.toBitmap()
+	}
+}
+
+// nonEmpty returns nil for an empty container
+func nonEmpty(c container) container {
+	if c.cardinality() == 0 {
+		return nil
+	}
+	return c
+}
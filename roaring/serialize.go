@@ -0,0 +1,156 @@
+package roaring
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ErrInvalidFormat is returned by UnmarshalBinary when the data is not a serialized bitmap
+var ErrInvalidFormat = errors.New("roaring: invalid serialized bitmap")
+
+// magic starts every serialized bitmap and carries the format version
+var magic = [4]byte{'R', 'B', 'M', 1}
+
+// container kinds in the serialized form
+const (
+	kindArray byte = iota
+	kindBitmap
+	kindRun
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler with a portable little-endian format:
+//
+//	magic "RBM\x01", uint32 number of chunks, then for each chunk in increasing key order
+//	uint16 key, uint8 kind and the container:
+//	  array:  uint16 cardinality-1, then each value as uint16
+//	  bitmap: 1024 uint64 words, value v is bit v%64 of word v/64
+//	  run:    uint16 number of runs-1, then each run as uint16 start and uint16 last
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	data := append([]byte(nil), magic[:]...)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(b.keys)))
+	for i, c := range b.containers {
+		data = binary.LittleEndian.AppendUint16(data, b.keys[i])
+		switch c := c.(type) {
+		case *arrayContainer:
+			data = append(data, kindArray)
+			data = binary.LittleEndian.AppendUint16(data, uint16(len(c.values)-1))
+			for _, v := range c.values {
+				data = binary.LittleEndian.AppendUint16(data, v)
+			}
+		case *bitmapContainer:
+			data = append(data, kindBitmap)
+			for _, w := range c.words {
+				data = binary.LittleEndian.AppendUint64(data, w)
+			}
+		case *runContainer:
+			data = append(data, kindRun)
+			data = binary.LittleEndian.AppendUint16(data, uint16(len(c.runs)-1))
+			for _, rn := range c.runs {
+				data = binary.LittleEndian.AppendUint16(data, rn.start)
+				data = binary.LittleEndian.AppendUint16(data, rn.last)
+			}
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, decoding the form written by MarshalBinary
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	d := decoder{data: data}
+	if m := d.next(4); m == nil || [4]byte(m) != magic {
+		return ErrInvalidFormat
+	}
+	n := d.uint32()
+	if d.err || n > 1<<16 {
+		return ErrInvalidFormat
+	}
+
+	r := Bitmap{}
+	for i := uint32(0); i < n; i++ {
+		key := d.uint16()
+		if len(r.keys) > 0 && key <= r.keys[len(r.keys)-1] {
+			return ErrInvalidFormat
+		}
+		var c container
+		switch kind := d.next(1); {
+		case kind == nil:
+			return ErrInvalidFormat
+		case kind[0] == kindArray:
+			a := &arrayContainer{values: make([]uint16, int(d.uint16())+1)}
+			for j := range a.values {
+				a.values[j] = d.uint16()
+				if j > 0 && a.values[j] <= a.values[j-1] {
+					return ErrInvalidFormat
+				}
+			}
+			c = a
+		case kind[0] == kindBitmap:
+			bm := &bitmapContainer{}
+			for j := range bm.words {
+				bm.words[j] = d.uint64()
+			}
+			bm.countCard()
+			if bm.card == 0 {
+				return ErrInvalidFormat
+			}
+			c = bm
+		case kind[0] == kindRun:
+			rc := &runContainer{runs: make([]run, int(d.uint16())+1)}
+			for j := range rc.runs {
+				rc.runs[j] = run{start: d.uint16(), last: d.uint16()}
+				if rc.runs[j].last < rc.runs[j].start || j > 0 && rc.runs[j].start <= rc.runs[j-1].last {
+					return ErrInvalidFormat
+				}
+			}
+			c = rc
+		default:
+			return ErrInvalidFormat
+		}
+		if d.err {
+			return ErrInvalidFormat
+		}
+		r.append(key, c)
+	}
+	if d.err || len(d.data) != 0 {
+		return ErrInvalidFormat
+	}
+	*b = r
+	return nil
+}
+
+// decoder reads little-endian integers, setting err instead of failing when the data runs out
+type decoder struct {
+	data []byte
+	err  bool
+}
+
+func (d *decoder) next(n int) []byte {
+	if d.err || len(d.data) < n {
+		d.err = true
+		return nil
+	}
+	p := d.data[:n]
+	d.data = d.data[n:]
+	return p
+}
+
+func (d *decoder) uint16() uint16 {
+	if p := d.next(2); p != nil {
+		return binary.LittleEndian.Uint16(p)
+	}
+	return 0
+}
+
+func (d *decoder) uint32() uint32 {
+	if p := d.next(4); p != nil {
+		return binary.LittleEndian.Uint32(p)
+	}
+	return 0
+}
+
+func (d *decoder) uint64() uint64 {
+	if p := d.next(8); p != nil {
+		return binary.LittleEndian.Uint64(p)
+	}
+	return 0
+}
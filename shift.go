@@ -0,0 +1,90 @@
+package bitop
+
+import (
+	"math/bits"
+)
+
+// RotateLeft returns the unit rotated left by k bits within its own length, bits shifted out on the left come back on the right
+// A negative k rotates to the right
+func RotateLeft(b Unit, k int) Unit {
+	if b.leng == 0 {
+		return b
+	}
+	k %= b.leng
+	if k < 0 {
+		k += b.leng
+	}
+	value := b.value<<uint(k) | b.value>>uint(b.leng-k)
+	return Unit{value: value & mask(b.leng), leng: b.leng}
+}
+
+// RotateRight returns the unit rotated right by k bits within its own length, bits shifted out on the right come back on the left
+// A negative k rotates to the left
+func RotateRight(b Unit, k int) Unit {
+	return RotateLeft(b, -k)
+}
+
+// ShiftLeft returns the unit shifted left by k bits keeping its length, bits shifted out on the left are dropped
+// A negative k is treated as zero
+func ShiftLeft(b Unit, k int) Unit {
+	if k < 0 {
+		k = 0
+	}
+	return Unit{value: b.value << uint(k) & mask(b.leng), leng: b.leng}
+}
+
+// ShiftRight returns the unit shifted right by k bits keeping its length, zeros are shifted in on the left
+// A negative k is treated as zero
+func ShiftRight(b Unit, k int) Unit {
+	if k < 0 {
+		k = 0
+	}
+	return Unit{value: b.value >> uint(k), leng: b.leng}
+}
+
+// ShiftRightArithmetic returns the unit shifted right by k bits keeping its length, copies of the leftmost bit are shifted in
+// A negative k is treated as zero
+func ShiftRightArithmetic(b Unit, k int) Unit {
+	if k < 0 {
+		k = 0
+	}
+	if k > b.leng {
+		k = b.leng
+	}
+	shifted := ShiftRight(b, k)
+	if b.leng > 0 && GetBitAtIndex(b, 0) == 1 {
+		shifted.value |= mask(b.leng) &^ mask(b.leng-k)
+	}
+	return shifted
+}
+
+// ShiftLeftGrow returns the unit shifted left by k bits, growing its length by k so no bits are dropped
+// The length is capped at the size of a uint, dropping the leftmost bits beyond it
+func ShiftLeftGrow(b Unit, k int) Unit {
+	if k < 0 {
+		k = 0
+	}
+	leng := b.leng + k
+	if leng > bits.UintSize {
+		leng = bits.UintSize
+	}
+	return Unit{value: b.value << uint(k), leng: leng}
+}
+
+// ShiftRightGrow returns the unit shifted right by k bits, growing its length by k so no bits are dropped
+// The length is capped at the size of a uint
+func ShiftRightGrow(b Unit, k int) Unit {
+	if k < 0 {
+		k = 0
+	}
+	leng := b.leng + k
+	if leng > bits.UintSize {
+		leng = bits.UintSize
+	}
+	return Unit{value: b.value, leng: leng}
+}
+
+// mask returns a uint with the n lowest bits set
+func mask(n int) uint {
+	return 1<<uint(n) - 1
+}
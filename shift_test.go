@@ -0,0 +1,93 @@
+package bitop
+
+import (
+	"testing"
+)
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		k        int
+		expected Unit
+	}{
+		{
+			name:     "odd width",
+			b:        NewUnit(0b10011, 5),
+			k:        2,
+			expected: NewUnit(0b01110, 5),
+		},
+		{
+			name:     "negative",
+			b:        NewUnit(0b10011, 5),
+			k:        -1,
+			expected: NewUnit(0b11001, 5),
+		},
+		{
+			name:     "full turn",
+			b:        NewUnit(0b10011, 5),
+			k:        10,
+			expected: NewUnit(0b10011, 5),
+		},
+		{
+			name:     "leading zeroes",
+			b:        NewUnit(0b001, 3),
+			k:        1,
+			expected: NewUnit(0b010, 3),
+		},
+		{
+			name:     "full word",
+			b:        NewUnit(1<<63, 64),
+			k:        1,
+			expected: NewUnit(1, 64),
+		},
+		{
+			name:     "empty",
+			b:        NewUnit(0, 0),
+			k:        3,
+			expected: NewUnit(0, 0),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := RotateLeft(tc.b, tc.k)
+			if result != tc.expected {
+				t.Fatalf("[TestRotate][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			if back := RotateRight(result, tc.k); back != tc.b {
+				t.Fatalf("[TestRotate][%s]: Got %v, expected %v after RotateRight", tc.name, back, tc.b)
+			}
+		})
+	}
+}
+
+func TestShift(t *testing.T) {
+	t.Parallel()
+	b := NewUnit(0b10110, 5)
+	for _, tc := range []struct {
+		name     string
+		result   Unit
+		expected Unit
+	}{
+		{"ShiftLeft", ShiftLeft(b, 2), NewUnit(0b11000, 5)},
+		{"ShiftLeft past width", ShiftLeft(b, 7), NewUnit(0, 5)},
+		{"ShiftRight", ShiftRight(b, 2), NewUnit(0b00101, 5)},
+		{"ShiftRight negative", ShiftRight(b, -2), b},
+		{"ShiftRightArithmetic one", ShiftRightArithmetic(b, 2), NewUnit(0b11101, 5)},
+		{"ShiftRightArithmetic zero", ShiftRightArithmetic(NewUnit(0b01101, 5), 2), NewUnit(0b00011, 5)},
+		{"ShiftRightArithmetic past width", ShiftRightArithmetic(b, 9), NewUnit(0b11111, 5)},
+		{"ShiftLeftGrow", ShiftLeftGrow(b, 3), NewUnit(0b10110000, 8)},
+		{"ShiftLeftGrow capped", ShiftLeftGrow(NewUnit(0b11, 63), 2), NewUnit(0b1100, 64)},
+		{"ShiftRightGrow", ShiftRightGrow(b, 3), NewUnit(0b00010110, 8)},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if tc.result != tc.expected {
+				t.Fatalf("[TestShift][%s]: Got %v, expected %v", tc.name, tc.result, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package bitop
+
+import "math/bits"
+
+// The methods below mirror the functions of the package, but return a Unit with the correct length
+// instead of a bare uint, so calls can be chained such as u.Reverse().Flip().SplitAt(3)
+
+// Value returns the binary value of the unit
+func (b Unit) Value() uint {
+	return b.value
+}
+
+// Len returns the number of bits in the unit
+func (b Unit) Len() int {
+	return b.leng
+}
+
+// Slice returns the bits from index i to j, [i, j), indexes are clamped to the length of the unit
+func (b Unit) Slice(i, j int) Unit {
+	i, j = clamp(i, 0, b.leng), clamp(j, 0, b.leng)
+	if i > j {
+		i = j
+	}
+	return slice(b, i, j)
+}
+
+// SplitAt returns the unit in two halves at the index specified [0, ind)
+func (b Unit) SplitAt(ind int) []Unit {
+	if ind < 0 {
+		return []Unit{b}
+	}
+	ind = clamp(ind, 0, b.leng)
+	return []Unit{slice(b, 0, ind), slice(b, ind, b.leng)}
+}
+
+// TruncateFromRight returns the unit with `pos` bits trimmed off from the right
+func (b Unit) TruncateFromRight(pos int) Unit {
+	return slice(b, 0, b.leng-clamp(pos, 0, b.leng))
+}
+
+// TruncateFromLeft returns the unit truncated up to the index from the left, exclusive of the index `ind`
+func (b Unit) TruncateFromLeft(ind int) Unit {
+	return slice(b, clamp(ind, 0, b.leng), b.leng)
+}
+
+// ClearFromRight returns the unit with bits set to zero up to the index from the right, exclusive of the index `ind`
+func (b Unit) ClearFromRight(ind int) Unit {
+	return Unit{value: ClearFromRight(b, clamp(ind, 0, b.leng)), leng: b.leng}
+}
+
+// RemoveBit returns the unit with the bit at index removed, length of the unit decreases by one
+func (b Unit) RemoveBit(ind int) Unit {
+	if ind < 0 || ind >= b.leng {
+		return b
+	}
+	return Unit{value: RemoveBit(b, ind), leng: b.leng - 1}
+}
+
+// FlipAtIndex returns the unit with the bit at the specified index flipped
+func (b Unit) FlipAtIndex(ind int) Unit {
+	if ind < 0 || ind >= b.leng {
+		return b
+	}
+	return Unit{value: FlipAtIndex(b, ind), leng: b.leng}
+}
+
+// Flip returns the unit with all bits flipped
+func (b Unit) Flip() Unit {
+	return Unit{value: Flip(b), leng: b.leng}
+}
+
+// Reverse returns the unit with bits in reversed order
+func (b Unit) Reverse() Unit {
+	return Unit{value: Reverse(b), leng: b.leng}
+}
+
+// Repeat returns the unit repeated for `count` number of repetitions
+// The length is capped at the size of a uint, dropping the leftmost bits beyond it; use RepeatErr to detect this
+func (b Unit) Repeat(count int) Unit {
+	if count < 0 {
+		count = 0
+	}
+	leng := bits.UintSize
+	if b.leng == 0 || count <= bits.UintSize/b.leng {
+		leng = b.leng * count
+	}
+	return Unit{value: Repeat(b, count), leng: leng}
+}
+
+// Replace returns the unit with any old bit pattern replaced by new, up to n times of occurrences
+// The length is capped at the size of a uint, dropping the leftmost bits beyond it; use ReplaceErr to detect this
+func (b Unit) Replace(old, new Unit, n int) Unit {
+	return replace(b, old, new, n)
+}
+
+// clamp returns x limited to [lo, hi]
+func clamp(x, lo, hi int) int {
+	if x < lo {
+		return lo
+	}
+	if x > hi {
+		return hi
+	}
+	return x
+}
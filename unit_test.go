@@ -0,0 +1,93 @@
+package bitop
+
+import (
+	"testing"
+)
+
+func TestUnitMethods(t *testing.T) {
+	t.Parallel()
+	b := NewUnit(0b0010110, 7)
+	for _, tc := range []struct {
+		name     string
+		result   Unit
+		expected Unit
+	}{
+		{"Slice", b.Slice(1, 5), NewUnit(0b0101, 4)},
+		{"Slice clamped", b.Slice(-3, 20), b},
+		{"Slice reversed", b.Slice(5, 1), NewUnit(0, 0)},
+		{"TruncateFromRight", b.TruncateFromRight(3), NewUnit(0b0010, 4)},
+		{"TruncateFromLeft", b.TruncateFromLeft(3), NewUnit(0b0110, 4)},
+		{"ClearFromRight", b.ClearFromRight(3), NewUnit(0b0010000, 7)},
+		{"RemoveBit", b.RemoveBit(2), NewUnit(0b000110, 6)},
+		{"RemoveBit out of range", b.RemoveBit(7), b},
+		{"FlipAtIndex", b.FlipAtIndex(0), NewUnit(0b1010110, 7)},
+		{"Flip", b.Flip(), NewUnit(0b1101001, 7)},
+		{"Reverse", b.Reverse(), NewUnit(0b0110100, 7)},
+		{"Repeat", NewUnit(0b01, 2).Repeat(3), NewUnit(0b010101, 6)},
+		{"Repeat overflow", NewUnit(0b1, 1).Repeat(70), NewUnit(^uint(0), 64)},
+		{"Repeat overflow drops leftmost bits", NewUnit(0b001, 3).Repeat(22), NewUnit(0x9249249249249249, 64)},
+		{"Replace", b.Replace(NewUnit(0b1, 1), NewUnit(0b00, 2), -1), b},
+		{"Replace growing", b.Replace(NewUnit(0b1, 1), NewUnit(0b00, 2), 2), NewUnit(0b000000010, 9)},
+		{"Replace overflow", NewUnit(0b1, 1).Repeat(40).Replace(NewUnit(0b1, 1), NewUnit(0b10, 2), 40), NewUnit(0xAAAAAAAAAAAAAAAA, 64)},
+		{"Replace empty old", NewUnit(0b101, 3).Replace(NewUnit(0, 0), NewUnit(0b11, 2), 10), NewUnit(0b11111111111111111111101, 23)},
+		{"Replace shrinking", b.Replace(NewUnit(0b0, 1), NewUnit(0, 0), 10), NewUnit(0b111, 3)},
+		{"chained", b.Reverse().Flip().Slice(0, 3), NewUnit(0b100, 3)},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if tc.result != tc.expected {
+				t.Fatalf("[TestUnitMethods][%s]: Got %v (%d bits), expected %v (%d bits)", tc.name, tc.result, tc.result.Len(), tc.expected, tc.expected.Len())
+			}
+		})
+	}
+}
+
+func TestUnitSplitAt(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		b        Unit
+		index    int
+		expected []Unit
+	}{
+		{
+			name:     "leading zeroes",
+			b:        NewUnit(0b000101, 6),
+			index:    2,
+			expected: []Unit{NewUnit(0b00, 2), NewUnit(0b0101, 4)},
+		},
+		{
+			name:     "negative",
+			b:        NewUnit(0b0101, 4),
+			index:    -1,
+			expected: []Unit{NewUnit(0b0101, 4)},
+		},
+		{
+			name:     "past end",
+			b:        NewUnit(0b0101, 4),
+			index:    9,
+			expected: []Unit{NewUnit(0b0101, 4), NewUnit(0, 0)},
+		},
+		{
+			name:     "chained",
+			b:        NewUnit(0b0010110, 7).Reverse().Flip(),
+			index:    3,
+			expected: []Unit{NewUnit(0b100, 3), NewUnit(0b1011, 4)},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := tc.b.SplitAt(tc.index)
+			if len(result) != len(tc.expected) {
+				t.Fatalf("[TestUnitSplitAt][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+			}
+			for i, r := range result {
+				if r != tc.expected[i] {
+					t.Fatalf("[TestUnitSplitAt][%s]: Got %v, expected %v", tc.name, result, tc.expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package bitop
+
+import "math/bits"
+
+// XorBasis is a linear basis of binaries of a fixed width under XOR, answering which values are the XOR of a subset of the inserted ones
+// The basis is kept reduced, each vector has a distinct leading bit which is zero in all other vectors
+type XorBasis struct {
+	vecs  [bits.UintSize]uint
+	width int
+	size  int
+}
+
+// NewXorBasis returns an empty basis for binaries of the given width, at most the size of a uint
+func NewXorBasis(width int) *XorBasis {
+	return &XorBasis{width: clamp(width, 0, bits.UintSize)}
+}
+
+// Width returns the bit length of the binaries in the basis
+func (x *XorBasis) Width() int {
+	return x.width
+}
+
+// Size returns the number of vectors in the basis, the span holds 2^Size values
+func (x *XorBasis) Size() int {
+	return x.size
+}
+
+// Insert adds the binary to the basis, returns false if it is already the XOR of a subset or has bits set beyond the width
+func (x *XorBasis) Insert(u Unit) bool {
+	v, ok := x.reduce(u)
+	if !ok || v == 0 {
+		return false
+	}
+	top := bits.Len(v) - 1
+	for i := top + 1; i < x.width; i++ {
+		if x.vecs[i]>>top&1 == 1 {
+			x.vecs[i] ^= v
+		}
+	}
+	x.vecs[top] = v
+	x.size++
+	return true
+}
+
+// Contains returns true if the binary is the XOR of a subset of the basis, the empty subset gives zero
+func (x *XorBasis) Contains(u Unit) bool {
+	v, ok := x.reduce(u)
+	return ok && v == 0
+}
+
+// Max returns the largest XOR of any subset of the basis
+func (x *XorBasis) Max() Unit {
+	var v uint
+	for _, b := range x.vecs[:x.width] {
+		v ^= b
+	}
+	return Unit{value: v, leng: x.width}
+}
+
+// Min returns the smallest non-zero XOR of any subset of the basis, ok is false if the basis is empty
+func (x *XorBasis) Min() (u Unit, ok bool) {
+	for _, b := range x.vecs[:x.width] {
+		if b != 0 {
+			return Unit{value: b, leng: x.width}, true
+		}
+	}
+	return Unit{}, false
+}
+
+// KthSmallest returns the k-th smallest value of the span counting from zero, the 0th is zero itself
+// ok is false if k is negative or not less than 2^Size
+func (x *XorBasis) KthSmallest(k int) (u Unit, ok bool) {
+	if k < 0 || x.size < bits.UintSize-1 && k >= 1<<x.size {
+		return Unit{}, false
+	}
+	var v uint
+	for _, b := range x.vecs[:x.width] {
+		if b == 0 {
+			continue
+		}
+		if k&1 == 1 {
+			v ^= b
+		}
+		k >>= 1
+	}
+	return Unit{value: v, leng: x.width}, true
+}
+
+// Merge inserts every vector of y into the basis, vectors wider than the basis are skipped
+func (x *XorBasis) Merge(y *XorBasis) {
+	for _, b := range y.vecs[:y.width] {
+		if b != 0 {
+			x.Insert(Unit{value: b, leng: y.width})
+		}
+	}
+}
+
+// reduce XORs out every leading bit of u that has a basis vector, ok is false if u has bits set beyond the width
+func (x *XorBasis) reduce(u Unit) (v uint, ok bool) {
+	v = u.value & mask(u.leng)
+	if v&^mask(x.width) != 0 {
+		return 0, false
+	}
+	for i := x.width - 1; i >= 0; i-- {
+		if v>>i&1 == 1 && x.vecs[i] != 0 {
+			v ^= x.vecs[i]
+		}
+	}
+	return v, true
+}
@@ -0,0 +1,124 @@
+package bitop
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestXorBasis(t *testing.T) {
+	t.Parallel()
+	x := NewXorBasis(4)
+	for _, tc := range []struct {
+		u        Unit
+		expected bool
+	}{
+		{NewUnit(0b0110, 4), true},
+		{NewUnit(0b0011, 4), true},
+		{NewUnit(0b0101, 4), false},
+		{NewUnit(0, 4), false},
+		{NewUnit(0b10000, 5), false},
+		{NewUnit(0b1, 1), true},
+	} {
+		if result := x.Insert(tc.u); result != tc.expected {
+			t.Fatalf("[TestXorBasis][insert %v]: Got %v, expected %v", tc.u, result, tc.expected)
+		}
+	}
+	if x.Size() != 3 {
+		t.Fatalf("[TestXorBasis][size]: Got %v, expected %v", x.Size(), 3)
+	}
+	for _, tc := range []struct {
+		u        Unit
+		expected bool
+	}{
+		{NewUnit(0b0111, 4), true},
+		{NewUnit(0b1000, 4), false},
+		{NewUnit(0, 0), true},
+		{NewUnit(0b00111, 8), true},
+		{NewUnit(0b10000, 8), false},
+	} {
+		if result := x.Contains(tc.u); result != tc.expected {
+			t.Fatalf("[TestXorBasis][contains %v]: Got %v, expected %v", tc.u, result, tc.expected)
+		}
+	}
+	if result, expected := x.Max(), NewUnit(0b0111, 4); result != expected {
+		t.Fatalf("[TestXorBasis][max]: Got %v, expected %v", result, expected)
+	}
+	if result, ok := x.Min(); !ok || result != NewUnit(0b0001, 4) {
+		t.Fatalf("[TestXorBasis][min]: Got %v, expected %v", result, NewUnit(0b0001, 4))
+	}
+	if _, ok := NewXorBasis(4).Min(); ok {
+		t.Fatalf("[TestXorBasis][min empty]: Got %v, expected %v", ok, false)
+	}
+	for k, expected := range []uint{0, 1, 2, 3, 4, 5, 6, 7} {
+		if result, ok := x.KthSmallest(k); !ok || result != NewUnit(expected, 4) {
+			t.Fatalf("[TestXorBasis][kth %d]: Got %v, expected %v", k, result, NewUnit(expected, 4))
+		}
+	}
+	if _, ok := x.KthSmallest(8); ok {
+		t.Fatalf("[TestXorBasis][kth 8]: Got %v, expected %v", ok, false)
+	}
+}
+
+func TestXorBasisRandom(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		width := 1 + r.Intn(10)
+		x := NewXorBasis(width)
+		span := map[uint]bool{0: true}
+		inserts := r.Intn(8)
+		for i := 0; i < inserts; i++ {
+			v := uint(r.Intn(1 << width))
+			if inserted := x.Insert(NewUnit(v, width)); inserted == span[v] {
+				t.Fatalf("[TestXorBasisRandom][%d]: Got %v inserting %b, expected %v", trial, inserted, v, !span[v])
+			}
+			for s := range span {
+				span[s^v] = true
+			}
+		}
+
+		var values []uint
+		for v := range span {
+			values = append(values, v)
+		}
+		slices.Sort(values)
+		if len(values) != 1<<x.Size() {
+			t.Fatalf("[TestXorBasisRandom][%d]: Got size %v, expected %v values", trial, x.Size(), len(values))
+		}
+		for k, v := range values {
+			if result, _ := x.KthSmallest(k); result.Value() != v {
+				t.Fatalf("[TestXorBasisRandom][%d]: Got %v at %d, expected %b", trial, result, k, v)
+			}
+		}
+		if result := x.Max(); result.Value() != values[len(values)-1] {
+			t.Fatalf("[TestXorBasisRandom][%d]: Got max %v, expected %b", trial, result, values[len(values)-1])
+		}
+		for v := uint(0); v < 1<<width; v++ {
+			if x.Contains(NewUnit(v, width)) != span[v] {
+				t.Fatalf("[TestXorBasisRandom][%d]: Got %v for %b, expected %v", trial, !span[v], v, span[v])
+			}
+		}
+	}
+}
+
+func TestXorBasisMerge(t *testing.T) {
+	t.Parallel()
+	x, y := NewXorBasis(8), NewXorBasis(8)
+	x.Insert(NewUnit(0b10000001, 8))
+	x.Insert(NewUnit(0b01000001, 8))
+	y.Insert(NewUnit(0b11000001, 8))
+	y.Insert(NewUnit(0b00100000, 8))
+	x.Merge(y)
+	if x.Size() != 4 {
+		t.Fatalf("[TestXorBasisMerge]: Got size %v, expected %v", x.Size(), 4)
+	}
+	for _, u := range []Unit{NewUnit(0b00100000, 8), NewUnit(0b11000001, 8), NewUnit(0b11100001, 8)} {
+		if !x.Contains(u) {
+			t.Fatalf("[TestXorBasisMerge]: Got %v, expected %v for %v", false, true, u)
+		}
+	}
+	if x.Contains(NewUnit(0b00010000, 8)) {
+		t.Fatalf("[TestXorBasisMerge]: Got %v, expected %v for %v", true, false, NewUnit(0b00010000, 8))
+	}
+}